@@ -19,8 +19,13 @@ import (
 	uv "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/uv"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 )
 
+// ProjectLayerName is the name of the layer exporting the python project
+// path when it differs from the application directory.
+const ProjectLayerName = "project"
+
 type PackagerParameters interface {
 }
 
@@ -35,6 +40,11 @@ func Build(
 			return packit.BuildResult{}, packit.Fail.WithMessage("empty plan: %s", context.Plan)
 		}
 
+		projectPath, err := project.Path(context.WorkingDir)
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
 		var results []packit.BuildResult
 
 		orderedInstallers := []string{
@@ -136,10 +146,42 @@ func Build(
 			}
 		}
 
+		if projectPath != context.WorkingDir {
+			result, err := exportProjectPath(logger, context, projectPath)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+			results = append(results, result)
+		}
+
 		return combineResults(results...), nil
 	}
 }
 
+// exportProjectPath makes the effective python project path available to the
+// subsequent buildpacks through PYTHON_PROJECT_PATH.
+func exportProjectPath(logger scribe.Emitter, context packit.BuildContext, projectPath string) (packit.BuildResult, error) {
+	logger.Title("Exporting python project path")
+
+	layer, err := context.Layers.Get(ProjectLayerName)
+	if err != nil {
+		return packit.BuildResult{}, err
+	}
+
+	layer, err = layer.Reset()
+	if err != nil {
+		return packit.BuildResult{}, err
+	}
+
+	layer.Build = true
+	layer.BuildEnv.Default(project.EnvEffectiveProjectPath, projectPath)
+	logger.EnvironmentVariables(layer)
+
+	return packit.BuildResult{
+		Layers: []packit.Layer{layer},
+	}, nil
+}
+
 func combineResults(results ...packit.BuildResult) packit.BuildResult {
 	if len(results) < 1 {
 		return packit.BuildResult{}
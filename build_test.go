@@ -72,16 +72,21 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		pipDependencyManager  *dependencyfakes.DependencyManager
 		pipInstallProcess     *pipfakes.InstallProcess
 		pipSitePackageProcess *pipfakes.SitePackageProcess
+		pipInterpreterProcess *pipfakes.InterpreterProcess
 
 		// pipenv
 		pipenvDependencyManager  *dependencyfakes.DependencyManager
 		pipenvProcess            *pipenvfakes.InstallProcess
 		pipenvSitePackageProcess *pipenvfakes.SitePackageProcess
+		pipenvVirtualenvProcess  *pipenvfakes.VirtualenvInstallProcess
+		pipenvInterpreterProcess *pipenvfakes.InterpreterProcess
 
 		// poetry
 		poetryDependencyManager  *dependencyfakes.DependencyManager
 		poetryProcess            *poetryfakes.InstallProcess
 		poetrySitePackageProcess *poetryfakes.SitePackageProcess
+		poetryVirtualenvProcess  *poetryfakes.VirtualenvInstallProcess
+		poetryInterpreterProcess *poetryfakes.InterpreterProcess
 
 		// uv
 		uvDependencyManager *dependencyfakes.DependencyManager
@@ -171,6 +176,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		}
 		pipSitePackageProcess = &pipfakes.SitePackageProcess{}
 		pipSitePackageProcess.ExecuteCall.Returns.String = filepath.Join(layersDir, "pip", "lib", "python1.23", "site-packages")
+		pipInterpreterProcess = &pipfakes.InterpreterProcess{}
 
 		// pipenv
 		pipenvDependencyManager = &dependencyfakes.DependencyManager{}
@@ -201,6 +207,8 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		pipenvProcess = &pipenvfakes.InstallProcess{}
 		pipenvSitePackageProcess = &pipenvfakes.SitePackageProcess{}
 		pipenvSitePackageProcess.ExecuteCall.Returns.String = filepath.Join(layersDir, "pipenv", "lib", "python3.8", "site-packages")
+		pipenvVirtualenvProcess = &pipenvfakes.VirtualenvInstallProcess{}
+		pipenvInterpreterProcess = &pipenvfakes.InterpreterProcess{}
 
 		// poetry
 		poetryDependencyManager = &dependencyfakes.DependencyManager{}
@@ -230,6 +238,8 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		poetryProcess = &poetryfakes.InstallProcess{}
 		poetrySitePackageProcess = &poetryfakes.SitePackageProcess{}
 		poetrySitePackageProcess.ExecuteCall.Returns.String = filepath.Join(layersDir, "poetry", "lib", "python3.8", "site-packages")
+		poetryVirtualenvProcess = &poetryfakes.VirtualenvInstallProcess{}
+		poetryInterpreterProcess = &poetryfakes.InterpreterProcess{}
 
 		// uv
 		uvDependencyManager = &dependencyfakes.DependencyManager{}
@@ -302,20 +312,25 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 				DependencyManager:  pipDependencyManager,
 				InstallProcess:     pipInstallProcess,
 				SitePackageProcess: pipSitePackageProcess,
+				InterpreterProcess: pipInterpreterProcess,
 			},
 			pipenv.Pipenv: pipenv.PipEnvBuildParameters{
-				DependencyManager:  pipenvDependencyManager,
-				InstallProcess:     pipenvProcess,
-				SitePackageProcess: pipenvSitePackageProcess,
+				DependencyManager:        pipenvDependencyManager,
+				InstallProcess:           pipenvProcess,
+				SitePackageProcess:       pipenvSitePackageProcess,
+				VirtualenvInstallProcess: pipenvVirtualenvProcess,
+				InterpreterProcess:       pipenvInterpreterProcess,
 			},
 			pixi.Pixi: pixi.PixiBuildParameters{
 				DependencyManager: pixiDependencyManager,
 				InstallProcess:    pixiInstallProcess,
 			},
 			poetry.PoetryDependency: poetry.PoetryBuildParameters{
-				DependencyManager:  poetryDependencyManager,
-				InstallProcess:     poetryProcess,
-				SitePackageProcess: poetrySitePackageProcess,
+				DependencyManager:        poetryDependencyManager,
+				InstallProcess:           poetryProcess,
+				SitePackageProcess:       poetrySitePackageProcess,
+				VirtualenvInstallProcess: poetryVirtualenvProcess,
+				InterpreterProcess:       poetryInterpreterProcess,
 			},
 			uv.Uv: uv.UvBuildParameters{
 				DependencyManager: uvDependencyManager,
@@ -481,6 +496,40 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		}
 	})
 
+	context("when BP_PYTHON_PROJECT_PATH is set", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+		})
+
+		it("exports the effective project path to the subsequent buildpacks", func() {
+			buildContext.Plan = testPlans[0].Plan
+			result, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(HaveLen(2))
+			projectLayer := result.Layers[1]
+			Expect(projectLayer.Name).To(Equal(pythoninstallers.ProjectLayerName))
+			Expect(projectLayer.Build).To(BeTrue())
+			Expect(projectLayer.Launch).To(BeFalse())
+			Expect(projectLayer.BuildEnv).To(Equal(packit.Environment{
+				"PYTHON_PROJECT_PATH.default": filepath.Join(workingDir, "services", "api"),
+			}))
+		})
+
+		context("when the path does not exist", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+			})
+
+			it("returns an error", func() {
+				buildContext.Plan = testPlans[0].Plan
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`)))
+			})
+		})
+	})
+
 	it("fails if packager parameters is missing", func() {
 		packagerParameters := map[string]pythoninstallers.PackagerParameters{}
 
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitLint(t *testing.T) {
+	suite := spec.New("lint", spec.Report(report.Terminal{}))
+	suite("Lint", testLint)
+	suite.Run(t)
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pep440"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
+)
+
+// checksumPattern is a checksum with its algorithm prefix.
+var checksumPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+
+// Problem is an inconsistency of buildpack.toml.
+type Problem struct {
+	Subject string
+	Message string
+}
+
+func (problem Problem) String() string {
+	return fmt.Sprintf("%s: %s", problem.Subject, problem.Message)
+}
+
+// Lint returns the inconsistencies of config, the content of a
+// buildpack.toml, sorted by subject.
+func Lint(config cargo.Config) []Problem {
+	var problems []Problem
+	report := func(subject, format string, a ...any) {
+		problems = append(problems, Problem{Subject: subject, Message: fmt.Sprintf(format, a...)})
+	}
+
+	versions := map[string][]*semver.Version{}
+	arches := map[string]map[string][]string{}
+
+	// postal fails to resolve a version when several of its dependencies
+	// for the same platform support the wildcard stack
+	wildcards := map[string]int{}
+
+	for _, dependency := range config.Metadata.Dependencies {
+		subject := dependencySubject(dependency)
+
+		if dependency.ID == "" {
+			report(subject, "missing id")
+			continue
+		}
+
+		version, err := semver.NewVersion(dependency.Version)
+		if err != nil {
+			report(subject, "invalid version %q", dependency.Version)
+		} else {
+			versions[dependency.ID] = append(versions[dependency.ID], version)
+		}
+
+		if arches[dependency.ID] == nil {
+			arches[dependency.ID] = map[string][]string{}
+		}
+		arches[dependency.ID][dependency.Version] = append(arches[dependency.ID][dependency.Version], dependency.Arch)
+
+		if slices.Contains(dependency.Stacks, "*") {
+			platform := fmt.Sprintf("%s %s %s/%s", dependency.ID, dependency.Version, dependency.OS, dependency.Arch)
+			wildcards[platform]++
+			if wildcards[platform] == 2 {
+				report(subject, "duplicated for the wildcard stack")
+			}
+		}
+
+		if dependency.URI == "" {
+			report(subject, "missing uri")
+		}
+
+		if dependency.SHA256 != "" {
+			report(subject, "uses the legacy sha256 field instead of checksum")
+		}
+
+		if dependency.SourceSHA256 != "" {
+			report(subject, "uses the legacy source_sha256 field instead of source-checksum")
+		}
+
+		if dependency.Checksum == "" && dependency.SHA256 == "" {
+			report(subject, "missing checksum")
+		}
+
+		for field, checksum := range map[string]string{"checksum": dependency.Checksum, "source-checksum": dependency.SourceChecksum} {
+			if checksum != "" && !checksumPattern.MatchString(checksum) {
+				report(subject, "%s %q is not of the form sha256:<hex digest>", field, checksum)
+			}
+		}
+
+		// The CPE and PURL of python packages hold the PEP 440 version they
+		// were published with
+		upstreamVersions := []string{dependency.Version, pep440.FromSemver(dependency.Version)}
+
+		if dependency.CPE != "" {
+			if fields := strings.Split(dependency.CPE, ":"); len(fields) < 6 || !slices.Contains(upstreamVersions, fields[5]) {
+				report(subject, "cpe %q does not match the version", dependency.CPE)
+			}
+		}
+
+		// A wheelhouse is compiled from the release of its tool
+		purlID := dependency.ID
+		if tool, _, ok := wheelhouse.Parse(dependency.ID); ok {
+			purlID = tool
+		}
+
+		if dependency.PURL != "" && !slices.ContainsFunc(upstreamVersions, func(version string) bool {
+			return strings.Contains(dependency.PURL, fmt.Sprintf("/%s@%s", purlID, version))
+		}) {
+			report(subject, "purl %q does not match the id and version", dependency.PURL)
+		}
+	}
+
+	var targetArches []string
+	for _, target := range config.Targets {
+		targetArches = append(targetArches, target.Arch)
+	}
+
+	for id, byVersion := range arches {
+		for version, dependencyArches := range byVersion {
+			// Dependencies without architecture run on any target
+			if slices.Contains(dependencyArches, "") {
+				continue
+			}
+
+			for _, arch := range targetArches {
+				if !slices.Contains(dependencyArches, arch) {
+					report(fmt.Sprintf("%s %s", id, version), "missing for the %s target", arch)
+				}
+			}
+		}
+	}
+
+	for _, constraint := range config.Metadata.DependencyConstraints {
+		subject := fmt.Sprintf("dependency-constraint %s %s", constraint.ID, constraint.Constraint)
+
+		c, err := semver.NewConstraint(constraint.Constraint)
+		if err != nil {
+			report(subject, "invalid constraint")
+			continue
+		}
+
+		if constraint.Patches <= 0 {
+			report(subject, "patches must be positive, got %d", constraint.Patches)
+		}
+
+		if !slices.ContainsFunc(versions[constraint.ID], c.Check) {
+			report(subject, "matches no version of %s", constraint.ID)
+		}
+	}
+
+	for id, defaultVersion := range config.Metadata.DefaultVersions {
+		subject := fmt.Sprintf("default-version %s %s", id, defaultVersion)
+
+		c, err := semver.NewConstraint(defaultVersion)
+		if err != nil {
+			report(subject, "invalid constraint")
+			continue
+		}
+
+		if !slices.ContainsFunc(versions[id], c.Check) {
+			report(subject, "matches no version of %s", id)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Subject < problems[j].Subject
+	})
+
+	return problems
+}
+
+func dependencySubject(dependency cargo.ConfigMetadataDependency) string {
+	subject := fmt.Sprintf("%s %s", dependency.ID, dependency.Version)
+	if dependency.Arch != "" {
+		subject += fmt.Sprintf(" (%s)", dependency.Arch)
+	}
+	return strings.TrimSpace(subject)
+}
@@ -0,0 +1,214 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+func testLint(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		config cargo.Config
+	)
+
+	messages := func(problems []Problem) []string {
+		var messages []string
+		for _, problem := range problems {
+			messages = append(messages, problem.String())
+		}
+		return messages
+	}
+
+	it.Before(func() {
+		checksum := "sha256:" + strings.Repeat("a", 64)
+
+		config = cargo.Config{
+			Targets: []cargo.ConfigTarget{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm64"}},
+			Metadata: cargo.ConfigMetadata{
+				DefaultVersions: map[string]string{"pip": "26.*", "uv": "0.10.*"},
+				Dependencies: []cargo.ConfigMetadataDependency{
+					{
+						ID:             "pip",
+						Version:        "26.0.1",
+						URI:            "https://example.com/pip.tgz",
+						Checksum:       checksum,
+						SourceChecksum: checksum,
+						CPE:            "cpe:2.3:a:pypa:pip:26.0.1:*:*:*:*:python:*:*",
+						PURL:           "pkg:generic/pip@26.0.1?checksum=aaa",
+					},
+					{ID: "uv", Version: "0.10.11", Arch: "amd64", URI: "https://example.com/uv-x86_64.tgz", Checksum: checksum},
+					{ID: "uv", Version: "0.10.11", Arch: "arm64", URI: "https://example.com/uv-aarch64.tgz", Checksum: checksum},
+				},
+				DependencyConstraints: []cargo.ConfigMetadataDependencyConstraint{
+					{ID: "pip", Constraint: "*", Patches: 2},
+					{ID: "uv", Constraint: "0.10.*", Patches: 2},
+				},
+			},
+		}
+	})
+
+	it("finds no problem in a consistent buildpack.toml", func() {
+		Expect(Lint(config)).To(BeEmpty())
+	})
+
+	it("finds no problem in the buildpack.toml of the buildpack", func() {
+		count, err := run("../../buildpack.toml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(0))
+	})
+
+	context("when a dependency is inconsistent", func() {
+		it.Before(func() {
+			config.Metadata.Dependencies[0] = cargo.ConfigMetadataDependency{
+				ID:             "pip",
+				Version:        "26.0.1",
+				SHA256:         strings.Repeat("a", 64),
+				SourceSHA256:   strings.Repeat("a", 64),
+				SourceChecksum: strings.Repeat("a", 64),
+				CPE:            "cpe:2.3:a:pypa:pip:26.0.0:*:*:*:*:python:*:*",
+				PURL:           "pkg:generic/pip@26.0.0?checksum=aaa",
+			}
+			config.Metadata.Dependencies[1].Checksum = ""
+		})
+
+		it("reports each of its problems", func() {
+			Expect(messages(Lint(config))).To(ConsistOf(
+				"pip 26.0.1: missing uri",
+				"pip 26.0.1: uses the legacy sha256 field instead of checksum",
+				"pip 26.0.1: uses the legacy source_sha256 field instead of source-checksum",
+				`pip 26.0.1: source-checksum "`+strings.Repeat("a", 64)+`" is not of the form sha256:<hex digest>`,
+				`pip 26.0.1: cpe "cpe:2.3:a:pypa:pip:26.0.0:*:*:*:*:python:*:*" does not match the version`,
+				`pip 26.0.1: purl "pkg:generic/pip@26.0.0?checksum=aaa" does not match the id and version`,
+				"uv 0.10.11 (amd64): missing checksum",
+			))
+		})
+	})
+
+	context("when a python package is a post release", func() {
+		it.Before(func() {
+			config.Metadata.Dependencies[0].Version = "26.0.1+post1"
+			config.Metadata.Dependencies[0].CPE = "cpe:2.3:a:pypa:pip:26.0.1.post1:*:*:*:*:python:*:*"
+			config.Metadata.Dependencies[0].PURL = "pkg:generic/pip@26.0.1.post1?checksum=aaa"
+		})
+
+		it("accepts the PEP 440 version in the cpe and purl", func() {
+			Expect(Lint(config)).To(BeEmpty())
+		})
+	})
+
+	context("when a wheelhouse is compiled from a python package", func() {
+		it.Before(func() {
+			for _, arch := range []string{"amd64", "arm64"} {
+				config.Metadata.Dependencies = append(config.Metadata.Dependencies, cargo.ConfigMetadataDependency{
+					ID:       "pip-cp312",
+					Version:  "26.0.1",
+					OS:       "linux",
+					Arch:     arch,
+					Stacks:   []string{"*"},
+					URI:      "https://example.com/pip-cp312.tgz",
+					Checksum: "sha256:" + strings.Repeat("a", 64),
+					PURL:     "pkg:generic/pip@26.0.1?checksum=aaa",
+				})
+			}
+		})
+
+		it("accepts the purl of the package", func() {
+			Expect(Lint(config)).To(BeEmpty())
+		})
+	})
+
+	context("when dependencies of a platform share the wildcard stack", func() {
+		it.Before(func() {
+			for i := range config.Metadata.Dependencies {
+				config.Metadata.Dependencies[i].OS = "linux"
+				config.Metadata.Dependencies[i].Stacks = []string{"*"}
+			}
+
+			duplicate := config.Metadata.Dependencies[2]
+			duplicate.URI = "https://example.com/uv-aarch64-other.tgz"
+			config.Metadata.Dependencies = append(config.Metadata.Dependencies, duplicate, duplicate)
+		})
+
+		it("reports the duplicates once", func() {
+			Expect(messages(Lint(config))).To(ConsistOf("uv 0.10.11 (arm64): duplicated for the wildcard stack"))
+		})
+	})
+
+	context("when a version misses a target", func() {
+		it.Before(func() {
+			config.Metadata.Dependencies = config.Metadata.Dependencies[:2]
+		})
+
+		it("reports the missing architecture", func() {
+			Expect(messages(Lint(config))).To(ConsistOf("uv 0.10.11: missing for the arm64 target"))
+		})
+	})
+
+	context("when a version is invalid", func() {
+		it.Before(func() {
+			config.Metadata.Dependencies[0].Version = "latest"
+			config.Metadata.Dependencies[0].CPE = ""
+			config.Metadata.Dependencies[0].PURL = ""
+		})
+
+		it("reports it along with the constraints it leaves unsatisfied", func() {
+			Expect(messages(Lint(config))).To(ConsistOf(
+				`pip latest: invalid version "latest"`,
+				"default-version pip 26.*: matches no version of pip",
+				"dependency-constraint pip *: matches no version of pip",
+			))
+		})
+	})
+
+	context("when the constraints are unsatisfiable", func() {
+		it.Before(func() {
+			config.Metadata.DependencyConstraints = append(config.Metadata.DependencyConstraints,
+				cargo.ConfigMetadataDependencyConstraint{ID: "uv", Constraint: "0.11.*", Patches: 2},
+				cargo.ConfigMetadataDependencyConstraint{ID: "uv", Constraint: "0.10.*", Patches: 0},
+				cargo.ConfigMetadataDependencyConstraint{ID: "pixi", Constraint: "not a constraint", Patches: 2},
+			)
+		})
+
+		it("reports them", func() {
+			Expect(messages(Lint(config))).To(ConsistOf(
+				"dependency-constraint uv 0.11.*: matches no version of uv",
+				"dependency-constraint uv 0.10.*: patches must be positive, got 0",
+				"dependency-constraint pixi not a constraint: invalid constraint",
+			))
+		})
+	})
+
+	context("when the default versions do not exist", func() {
+		it.Before(func() {
+			config.Metadata.DefaultVersions["uv"] = "0.11.*"
+			config.Metadata.DefaultVersions["pixi"] = "0.66.*"
+			config.Metadata.DefaultVersions["poetry"] = "~> latest"
+		})
+
+		it("reports them", func() {
+			Expect(messages(Lint(config))).To(ConsistOf(
+				"default-version uv 0.11.*: matches no version of uv",
+				"default-version pixi 0.66.*: matches no version of pixi",
+				"default-version poetry ~> latest: invalid constraint",
+			))
+		})
+	})
+
+	context("when buildpack.toml cannot be read", func() {
+		it("returns an error", func() {
+			_, err := run("no-such-buildpack.toml")
+			Expect(err).To(MatchError(ContainSubstring("no such file or directory")))
+		})
+	})
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command lint checks the consistency of buildpack.toml: the dependencies,
+// their checksums, CPE and PURL, the dependency constraints and the default
+// versions.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+)
+
+func run(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var config cargo.Config
+	if err := cargo.DecodeConfig(file, &config); err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	problems := Lint(config)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+
+	return len(problems), nil
+}
+
+func main() {
+	var buildpackTomlPath string
+	flag.StringVar(&buildpackTomlPath, "buildpack-toml-path", "buildpack.toml", "path to the buildpack.toml file to check")
+	flag.Parse()
+
+	count, err := run(buildpackTomlPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	if count > 0 {
+		fmt.Fprintf(os.Stderr, "%s has %d problems\n", buildpackTomlPath, count)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s is consistent\n", buildpackTomlPath)
+}
@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command pep440 prints the PEP 440 version a python package listed in
+// buildpack.toml was published with, e.g. 2024.0.1.post1 for
+// 2024.0.1+post1, for the compilation to download it from PyPI.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pep440"
+)
+
+func main() {
+	var version string
+	flag.StringVar(&version, "version", "", "version of the dependency in buildpack.toml")
+	flag.Parse()
+
+	if version == "" {
+		fmt.Fprintln(os.Stderr, "Error: --version is required")
+		os.Exit(1)
+	}
+
+	fmt.Println(pep440.FromSemver(version))
+}
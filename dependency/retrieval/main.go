@@ -9,569 +9,406 @@ package main
 
 import (
 	"bytes"
-	"context"
-	"crypto/sha256"
+	_ "embed"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"net/http"
+	"maps"
 	"os"
-	"regexp"
-	"strconv"
+	"slices"
 	"strings"
-	"time"
+	"text/tabwriter"
 
-	"github.com/Masterminds/semver/v3"
-	"github.com/google/go-github/v81/github"
 	"github.com/joshuatcasey/libdependency/buildpack_config"
 	"github.com/joshuatcasey/libdependency/retrieve"
 	"github.com/joshuatcasey/libdependency/upstream"
 	"github.com/joshuatcasey/libdependency/versionology"
-	"github.com/nfx/go-htmltable"
 	"github.com/paketo-buildpacks/packit/v2/cargo"
 	"github.com/paketo-buildpacks/packit/v2/fs"
-)
-
-type PyPiProductMetadataRaw struct {
-	Releases map[string][]struct {
-		PackageType string            `json:"packagetype"`
-		URL         string            `json:"url"`
-		UploadTime  string            `json:"upload_time_iso_8601"`
-		Digests     map[string]string `json:"digests"`
-	} `json:"releases"`
-}
-
-type PyPiRelease struct {
-	version      *semver.Version
-	SourceURL    string
-	UploadTime   time.Time
-	SourceSHA256 string
-}
 
-func (release PyPiRelease) Version() *semver.Version {
-	return release.version
-}
-
-func getAllVersionsForInstaller(installer string) retrieve.GetAllVersionsFunc {
-	fmt.Printf("Handling: %s\n", installer)
-	if installer == "miniconda3" {
-		return getAllMinicondaVersions
-	}
-
-	if installer == "uv" {
-		return getAllUvVersions
-	}
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/sources"
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/wheelhouse"
+)
 
-	if installer == "pixi" {
-		return getAllPixiVersions
-	}
+// defaultConfig describes the dependencies of the buildpack, see
+// sources.Config.
+//
+//go:embed sources.toml
+var defaultConfig []byte
 
+func getAllVersions(dependency sources.Dependency, constraints []sources.Constraint, upstream sources.Upstream) retrieve.GetAllVersionsFunc {
 	return func() (versionology.VersionFetcherArray, error) {
+		fmt.Printf("Handling: %s\n", dependency.ID)
 
-		var pypiMetadata PyPiProductMetadataRaw
-		err := upstream.GetAndUnmarshal(fmt.Sprintf("https://pypi.org/pypi/%s/json", installer), &pypiMetadata)
+		source, err := sources.NewSource(dependency.Source, upstream, constraints)
 		if err != nil {
-			return nil, fmt.Errorf("could not retrieve new versions from upstream: %w", err)
+			return nil, err
 		}
 
-		var allVersions versionology.VersionFetcherArray
+		releases, err := source.Releases()
+		if err != nil {
+			return nil, err
+		}
 
-		for version, releasesForVersion := range pypiMetadata.Releases {
-			for _, release := range releasesForVersion {
-				if release.PackageType != "sdist" {
-					continue
-				}
-
-				fmt.Printf("Parsing semver version %s\n", version)
-
-				newVersion, err := semver.NewVersion(version)
-				if err != nil {
-					continue
-				}
-
-				uploadTime, err := time.Parse(time.RFC3339, release.UploadTime)
-				if err != nil {
-					return nil, fmt.Errorf("could not parse upload time '%s' as date for version %s: %w", release.UploadTime, version, err)
-				}
-
-				allVersions = append(allVersions, PyPiRelease{
-					version:      newVersion,
-					SourceSHA256: release.Digests["sha256"],
-					SourceURL:    release.URL,
-					UploadTime:   uploadTime,
-				})
-			}
+		var allVersions versionology.VersionFetcherArray
+		for _, release := range releases {
+			allVersions = append(allVersions, release)
 		}
 
 		return allVersions, nil
 	}
 }
 
-func generatePipMetadata(versionFetcher versionology.VersionFetcher) ([]versionology.Dependency, error) {
-	version := versionFetcher.Version().String()
-	pipRelease, ok := versionFetcher.(PyPiRelease)
-	if !ok {
-		return nil, errors.New("expected a PyPiRelease")
-	}
-
-	configMetadataDependency := cargo.ConfigMetadataDependency{
-		CPE:            fmt.Sprintf("cpe:2.3:a:pypa:pip:%s:*:*:*:*:python:*:*", version),
-		ID:             "pip",
-		Licenses:       retrieve.LookupLicenses(pipRelease.SourceURL, upstream.DefaultDecompress),
-		PURL:           retrieve.GeneratePURL("pip", version, pipRelease.SourceSHA256, pipRelease.SourceURL),
-		Source:         pipRelease.SourceURL,
-		SourceChecksum: fmt.Sprintf("sha256:%s", pipRelease.SourceSHA256),
-		Stacks:         []string{"*"},
-		Version:        version,
-	}
-
-	return versionology.NewDependencyArray(configMetadataDependency, "noarch")
-}
-
-func generatePipenvMetadata(versionFetcher versionology.VersionFetcher) ([]versionology.Dependency, error) {
-	version := versionFetcher.Version().String()
-	pipenvRelease, ok := versionFetcher.(PyPiRelease)
-	if !ok {
-		return nil, errors.New("expected a PyPiRelease")
-	}
-
-	configMetadataDependency := cargo.ConfigMetadataDependency{
-		CPE:            fmt.Sprintf("cpe:2.3:a:python-pipenv:pipenv:%s:*:*:*:*:python:*:*", version),
-		Checksum:       fmt.Sprintf("sha256:%s", pipenvRelease.SourceSHA256),
-		ID:             "pipenv",
-		Licenses:       retrieve.LookupLicenses(pipenvRelease.SourceURL, upstream.DefaultDecompress),
-		Name:           "Pipenv",
-		PURL:           retrieve.GeneratePURL("pipenv", version, pipenvRelease.SourceSHA256, pipenvRelease.SourceURL),
-		Source:         pipenvRelease.SourceURL,
-		SourceChecksum: fmt.Sprintf("sha256:%s", pipenvRelease.SourceSHA256),
-		Stacks:         []string{"*"},
-		URI:            pipenvRelease.SourceURL,
-		Version:        version,
-	}
-
-	return []versionology.Dependency{{
-		ConfigMetadataDependency: configMetadataDependency,
-		SemverVersion:            versionFetcher.Version(),
-	}}, nil
-}
-
-func generatePoetryMetadata(versionFetcher versionology.VersionFetcher) ([]versionology.Dependency, error) {
-	version := versionFetcher.Version().String()
-	poetryRelease, ok := versionFetcher.(PyPiRelease)
-	if !ok {
-		return nil, errors.New("expected a PyPiRelease")
-	}
-
-	configMetadataDependency := cargo.ConfigMetadataDependency{
-		CPE:            fmt.Sprintf("cpe:2.3:a:python-poetry:poetry:%s:*:*:*:*:python:*:*", version),
-		Checksum:       fmt.Sprintf("sha256:%s", poetryRelease.SourceSHA256),
-		ID:             "poetry",
-		Licenses:       retrieve.LookupLicenses(poetryRelease.SourceURL, upstream.DefaultDecompress),
-		Name:           "Poetry",
-		PURL:           retrieve.GeneratePURL("poetry", version, poetryRelease.SourceSHA256, poetryRelease.SourceURL),
-		Source:         poetryRelease.SourceURL,
-		SourceChecksum: fmt.Sprintf("sha256:%s", poetryRelease.SourceSHA256),
-		Stacks:         []string{"*"},
-		URI:            poetryRelease.SourceURL,
-		Version:        version,
-	}
-
-	return []versionology.Dependency{{
-		ConfigMetadataDependency: configMetadataDependency,
-		SemverVersion:            versionFetcher.Version(),
-	}}, nil
-}
-
-type MinicondaRelease struct {
-	version      *semver.Version
-	fullVersion  *semver.Version
-	Arch         string
-	SourceURL    string
-	UploadTime   time.Time
-	SourceSHA256 string
-	BinaryURL    string
-	BinarySHA256 string
-}
-
-func (release MinicondaRelease) Version() *semver.Version {
-	return release.version
-}
-
-func (release MinicondaRelease) FullVersion() *semver.Version {
-	return release.fullVersion
-}
-
-type Miniconda struct {
-	Filename     string `header:"Filename"`
-	Size         int    `header:"Size"`
-	LastModified string `header:"Last Modified"`
-	SHA256       string `header:"SHA256"`
-}
-
-const MaxNumberOfMinicondaReleases = 8
-
-var ArchMap = map[string]string{
-	"x86_64":  "amd64",
-	"aarch64": "arm64",
-}
-
-// filtered returns the slice passed in parameter with the needle removed
-func filtered(haystack []Miniconda, needle *regexp.Regexp) []Miniconda {
-	output := []Miniconda{}
-
-	for _, entry := range haystack {
-		if needle.MatchString(entry.Filename) {
-			output = append(output, entry)
+func generateMetadata(dependency sources.Dependency) retrieve.GenerateMetadataFunc {
+	return func(versionFetcher versionology.VersionFetcher) ([]versionology.Dependency, error) {
+		release, ok := versionFetcher.(sources.Release)
+		if !ok {
+			return nil, errors.New("expected a sources.Release")
 		}
-	}
-
-	return output
-}
-
-func getAllMinicondaVersions() (versionology.VersionFetcherArray, error) {
-	url := "https://repo.anaconda.com/miniconda"
-	condaInstallersTable, err := htmltable.NewSliceFromURL[Miniconda](url)
-	if err != nil {
-		return nil, err
-	}
 
-	re := regexp.MustCompile(`Miniconda3-py39_(?P<Version>\d+.\d+.\d+(-\d+)?)-Linux-(?P<Arch>x86_64|aarch64)`)
-	matches := filtered(condaInstallersTable, re)
+		version := release.Version().String()
 
-	// Keeping only six most recent versions as otherwise it means spamming the release
-	// page from GitHub for getting the sha256 for the sources which will get throttled
-	kept := matches[:MaxNumberOfMinicondaReleases]
+		// The CPE and PURL identify the release as published upstream
+		upstreamVersion := release.Upstream()
 
-	// var allVersions versionology.VersionFetcherArray
-	// var allVersions []MinicondaRelease
-	filteredVersions := map[string]MinicondaRelease{}
-
-	for _, item := range kept {
-		matches := re.FindStringSubmatch(item.Filename)
-
-		fmt.Printf("Parsing semver version %s\n", matches[1])
-
-		fullVersion, err := semver.NewVersion(matches[1])
-		if err != nil {
-			continue
+		var licenses []interface{}
+		if len(dependency.Licenses) == 0 {
+			licenses = retrieve.LookupLicenses(release.SourceURL, upstream.DefaultDecompress)
 		}
-
-		originalVersion := strings.TrimSuffix(matches[1], matches[2])
-		newVersion, err := semver.NewVersion(originalVersion)
-		if err != nil {
-			continue
+		for _, license := range dependency.Licenses {
+			licenses = append(licenses, license)
 		}
 
-		versionString := fmt.Sprintf("%s-%s", newVersion.String(), matches[3])
-		if knownVersion, ok := filteredVersions[versionString]; ok {
-			knownPrerelease, err := strconv.Atoi(knownVersion.FullVersion().Prerelease())
-			if err != nil {
-				return nil, fmt.Errorf("could not parse prerelease value %s: %s", knownVersion.FullVersion().Prerelease(), err)
-			}
-			newPrerelease, err := strconv.Atoi(fullVersion.Prerelease())
-			if err != nil {
-				return nil, fmt.Errorf("could not parse prerelease value %s: %s", fullVersion.Prerelease(), err)
-			}
-
-			if knownPrerelease > newPrerelease {
-				fmt.Println("skip", fullVersion, "as more recent version (", knownVersion.FullVersion(), ") was already found")
-				continue
-			}
+		configMetadataDependency := cargo.ConfigMetadataDependency{
+			CPE:            sources.Expand(dependency.CPE, upstreamVersion, ""),
+			ID:             dependency.ID,
+			Licenses:       licenses,
+			Name:           dependency.Name,
+			PURL:           retrieve.GeneratePURL(dependency.ID, upstreamVersion, release.SourceSHA256, release.SourceURL),
+			Source:         release.SourceURL,
+			SourceChecksum: fmt.Sprintf("sha256:%s", release.SourceSHA256),
+			Stacks:         []string{"*"},
+			Version:        version,
 		}
 
-		uploadTime, err := time.Parse(time.DateTime, item.LastModified)
-		if err != nil {
-			return nil, fmt.Errorf("could not parse upload time '%s' as date for version %s: %w", item.LastModified, fullVersion, err)
+		switch {
+		case release.BinaryURL != "":
+			configMetadataDependency.Checksum = fmt.Sprintf("sha256:%s", release.BinarySHA256)
+			configMetadataDependency.URI = release.BinaryURL
+			configMetadataDependency.OS = "linux"
+			configMetadataDependency.Arch = release.Arch
+		case dependency.Target == "" || dependency.Target == sources.WheelhouseTarget:
+			// Used as published, the source distribution is the artifact. The
+			// wheelhouses compiled from it have their own entries.
+			configMetadataDependency.Checksum = fmt.Sprintf("sha256:%s", release.SourceSHA256)
+			configMetadataDependency.URI = release.SourceURL
 		}
 
-		sha256URL := fmt.Sprintf("https://github.com/conda/conda/releases/download/%s/conda-%s.tar.gz.sha256sum", originalVersion, originalVersion)
-		resp, err := http.Get(sha256URL)
-		if err != nil {
-			return nil, err
+		if dependency.Target == sources.WheelhouseTarget {
+			return wheelhouses(dependency, configMetadataDependency, release)
 		}
-		defer resp.Body.Close()
 
-		sourceSHA256, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read body of sha256 request")
+		if dependency.Target != "" {
+			return versionology.NewDependencyArray(configMetadataDependency, dependency.Target)
 		}
 
-		sourceSHA256 = bytes.TrimSpace(sourceSHA256)
+		return []versionology.Dependency{{
+			ConfigMetadataDependency: configMetadataDependency,
+			SemverVersion:            versionFetcher.Version(),
+		}}, nil
+	}
+}
 
-		if len(sourceSHA256) != sha256.BlockSize {
-			return nil, fmt.Errorf("source sha256 does not have the correct size: %d vs %d", len(sourceSHA256), sha256.BlockSize)
-		}
+// wheelhouses returns the source distribution of the release followed by the
+// dependencies to compile into a wheelhouse for each python supported by the
+// release and each architecture, under the id of its ABI, see wheelhouse.ID.
+func wheelhouses(dependency sources.Dependency, configMetadataDependency cargo.ConfigMetadataDependency, release sources.Release) ([]versionology.Dependency, error) {
+	targets, err := dependency.WheelhouseTargets(release.RequiresPython)
+	if err != nil {
+		return nil, err
+	}
 
-		filteredVersions[versionString] = MinicondaRelease{
-			version:      newVersion,
-			fullVersion:  fullVersion,
-			Arch:         ArchMap[matches[3]],
-			BinaryURL:    fmt.Sprintf("%s/%s", url, item.Filename),
-			BinarySHA256: item.SHA256,
-			SourceURL:    fmt.Sprintf("https://github.com/conda/conda/releases/download/%s/conda-%s.tar.gz", originalVersion, originalVersion),
-			SourceSHA256: string(sourceSHA256),
-			UploadTime:   uploadTime,
+	architectures := slices.Sorted(maps.Values(sources.ArchMap))
+
+	dependencies := []versionology.Dependency{{
+		ConfigMetadataDependency: configMetadataDependency,
+		SemverVersion:            release.Version(),
+	}}
+	for _, target := range targets {
+		for _, arch := range architectures {
+			entry := configMetadataDependency
+			entry.ID = wheelhouse.ID(dependency.ID, strings.TrimPrefix(target, sources.WheelhouseTarget+"-"))
+			entry.Checksum = ""
+			entry.URI = ""
+			entry.OS = "linux"
+			entry.Arch = arch
+
+			dependencies = append(dependencies, versionology.Dependency{
+				ConfigMetadataDependency: entry,
+				SemverVersion:            release.Version(),
+				Target:                   target,
+			})
 		}
 	}
 
-	var result versionology.VersionFetcherArray
-	for _, version := range filteredVersions {
-		result = append(result, version)
-	}
-	return result, nil
+	return dependencies, nil
+}
+
+// RetrievedDependency is a dependency along with the python versions it
+// supports, which cargo.ConfigMetadataDependency has no field for.
+type RetrievedDependency struct {
+	versionology.Dependency
+	RequiresPython string
 }
 
-func generateMinicondaMetadata(versionFetcher versionology.VersionFetcher) ([]versionology.Dependency, error) {
-	version := versionFetcher.Version().String()
-	minicondaRelease, ok := versionFetcher.(MinicondaRelease)
-	if !ok {
-		return nil, errors.New("expected a MinicondaRelease")
+func (dependency RetrievedDependency) MarshalJSON() ([]byte, error) {
+	content, err := json.Marshal(dependency.Dependency)
+	if err != nil {
+		return nil, err
 	}
 
-	var licenseIDsAsInterface []interface{}
-	licenseIDsAsInterface = append(licenseIDsAsInterface, "BSD-3-Clause")
-	configMetadataDependency := cargo.ConfigMetadataDependency{
-		CPE:            fmt.Sprintf("cpe:2.3:a:conda:miniconda3:%s:*:*:*:*:python:*:*", version),
-		Checksum:       fmt.Sprintf("sha256:%s", minicondaRelease.BinarySHA256),
-		ID:             "miniconda3",
-		Licenses:       licenseIDsAsInterface,
-		Name:           "Miniconda.sh",
-		OS:             "linux",
-		Arch:           minicondaRelease.Arch,
-		PURL:           retrieve.GeneratePURL("miniconda3", version, minicondaRelease.SourceSHA256, minicondaRelease.SourceURL),
-		Source:         minicondaRelease.SourceURL,
-		SourceChecksum: fmt.Sprintf("sha256:%s", minicondaRelease.SourceSHA256),
-		Stacks:         []string{"*"},
-		URI:            minicondaRelease.BinaryURL,
-		Version:        version,
+	if dependency.RequiresPython == "" {
+		return content, nil
 	}
 
-	return []versionology.Dependency{{
-		ConfigMetadataDependency: configMetadataDependency,
-		SemverVersion:            versionFetcher.Version(),
-	}}, nil
-}
+	var fields map[string]any
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, err
+	}
+	fields["requires-python"] = dependency.RequiresPython
 
-type GitHubRelease struct {
-	version      *semver.Version
-	Arch         string
-	SourceURL    string
-	UploadTime   time.Time
-	SourceSHA256 string
-	BinaryURL    string
-	BinarySHA256 string
+	return json.Marshal(fields)
 }
 
-func (release GitHubRelease) Version() *semver.Version {
-	return release.version
+// Taken from libdependency.retrieve.retrieval
+// https://github.com/joshuatcasey/libdependency/blob/main/retrieve/retrieval.go
+func toWorkflowJson(item any) (string, error) {
+	if bytes, err := json.Marshal(item); err != nil {
+		return "", err
+	} else {
+		return string(bytes), nil
+	}
 }
 
-func getGitHubVersions(org string, project string, archAsset string) (versionology.VersionFetcherArray, error) {
-	client := github.NewClient(nil)
+// dependencyConstraints returns the dependency constraints of config for id.
+func dependencyConstraints(config cargo.Config, id string) []sources.Constraint {
+	var constraints []sources.Constraint
+	for _, constraint := range config.Metadata.DependencyConstraints {
+		if constraint.ID == id {
+			constraints = append(constraints, sources.Constraint{
+				Constraint: constraint.Constraint,
+				Patches:    constraint.Patches,
+			})
+		}
+	}
+	return constraints
+}
 
-	opt := &github.ListOptions{Page: 1, PerPage: 4}
-	releases, _, err := client.Repositories.ListReleases(context.Background(), org, project, opt)
+// retrieveDependency returns the metadata of the versions of dependency not
+// yet in the buildpack.toml described by config.
+func retrieveDependency(dependency sources.Dependency, config cargo.Config, upstream sources.Upstream) (dependencies []RetrievedDependency, err error) {
+	// libdependency panics on some failures, they only concern this dependency
+	defer func() {
+		if r := recover(); r != nil {
+			dependencies, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
 
+	newVersions, err := retrieve.GetNewVersionsForId(dependency.ID, config, getAllVersions(dependency, dependencyConstraints(config, dependency.ID), upstream))
 	if err != nil {
 		return nil, err
 	}
 
-	var result versionology.VersionFetcherArray
-
-	for _, release := range releases {
-		version, err := semver.NewVersion(*release.TagName)
+	// This loop is taken from GenerateAllMetadata
+	// https://github.com/joshuatcasey/libdependency/blob/main/retrieve/retrieval.go
+	for _, version := range newVersions {
+		metadata, err := generateMetadata(dependency)(version)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to generate the metadata of %s: %w", version.Version(), err)
 		}
 
-		var sourceURL *string
-		var sourceSHA256 *string
-		for _, asset := range release.Assets {
-			if *asset.Name == "source.tar.gz" {
-				sourceURL = asset.BrowserDownloadURL
-				sourceSHA256 = asset.Digest
-				break
-			}
+		var targets []string
+		for _, metadatum := range metadata {
+			targets = append(targets, metadatum.Target)
 		}
-		if sourceURL == nil || sourceSHA256 == nil {
-			return nil, errors.New("Failed to find source asset")
+		fmt.Printf("Generating metadata for %s, with targets [%s]\n", version.Version().String(), strings.Join(targets, ", "))
+
+		var requiresPython string
+		if release, ok := version.(sources.Release); ok {
+			requiresPython = release.RequiresPython
 		}
 
-		for inArch, outArch := range ArchMap {
-			assetName := fmt.Sprintf(archAsset, inArch)
-			for _, asset := range release.Assets {
-				if *asset.Name == assetName {
-					result = append(result,
-						GitHubRelease{
-							version:      version,
-							Arch:         outArch,
-							BinaryURL:    *asset.BrowserDownloadURL,
-							BinarySHA256: *asset.Digest,
-							SourceURL:    *sourceURL,
-							SourceSHA256: *sourceSHA256,
-							UploadTime:   *asset.UpdatedAt.GetTime(),
-						})
-					break
-				}
+		for _, metadatum := range metadata {
+			retrieved := RetrievedDependency{Dependency: metadatum}
+			// The buildpack selects the version of the tool by its python
+			// support, the wheelhouses are then looked up by its version
+			if metadatum.ID == dependency.ID {
+				retrieved.RequiresPython = requiresPython
 			}
+			dependencies = append(dependencies, retrieved)
 		}
 	}
 
-	return result, nil
+	return dependencies, nil
 }
 
-func getAllUvVersions() (versionology.VersionFetcherArray, error) {
-	return getGitHubVersions("astral-sh", "uv", "uv-%s-unknown-linux-gnu.tar.gz")
+// Result is the outcome of the retrieval of a dependency.
+type Result struct {
+	ID           string
+	Dependencies []RetrievedDependency
+	Err          error
+	Skipped      bool
 }
 
-func generateUvMetadata(versionFetcher versionology.VersionFetcher) ([]versionology.Dependency, error) {
-	version := versionFetcher.Version().String()
-	uvRelease, ok := versionFetcher.(GitHubRelease)
-	if !ok {
-		return nil, errors.New("expected a GitHubRelease")
-	}
+// retrieveAll retrieves each of dependencies with retrieveFunc. It carries on
+// after a failure unless failFast is set, the dependencies left out are then
+// marked as skipped.
+func retrieveAll(dependencies []sources.Dependency, retrieveFunc func(sources.Dependency) ([]RetrievedDependency, error), failFast bool) []Result {
+	var results []Result
+	failed := false
+	for _, dependency := range dependencies {
+		if failed && failFast {
+			results = append(results, Result{ID: dependency.ID, Skipped: true})
+			continue
+		}
 
-	var licenseIDsAsInterface []interface{}
-	licenseIDsAsInterface = append(licenseIDsAsInterface, "Apache-2.0", "MIT")
-	configMetadataDependency := cargo.ConfigMetadataDependency{
-		CPE:            fmt.Sprintf("cpe:2.3:a:uv:uv:%s:*:*:*:*:python:*:*", version),
-		Checksum:       uvRelease.BinarySHA256,
-		ID:             "uv",
-		Licenses:       licenseIDsAsInterface,
-		Name:           "uv",
-		OS:             "linux",
-		Arch:           uvRelease.Arch,
-		PURL:           retrieve.GeneratePURL("uv", version, uvRelease.SourceSHA256, uvRelease.SourceURL),
-		Source:         uvRelease.SourceURL,
-		SourceChecksum: uvRelease.SourceSHA256,
-		Stacks:         []string{"*"},
-		URI:            uvRelease.BinaryURL,
-		Version:        version,
+		retrieved, err := retrieveFunc(dependency)
+		results = append(results, Result{ID: dependency.ID, Dependencies: retrieved, Err: err})
+		failed = failed || err != nil
 	}
-
-	return []versionology.Dependency{{
-		ConfigMetadataDependency: configMetadataDependency,
-		SemverVersion:            versionFetcher.Version(),
-	}}, nil
+	return results
 }
 
-func getAllPixiVersions() (versionology.VersionFetcherArray, error) {
-	return getGitHubVersions("prefix-dev", "pixi", "pixi-%s-unknown-linux-musl.tar.gz")
-}
+// writeSummary writes a table of results, one line per dependency.
+func writeSummary(w io.Writer, results []Result) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tSTATUS\tVERSIONS\tERROR")
+
+	for _, result := range results {
+		status, message := "ok", ""
+		switch {
+		case result.Err != nil:
+			status, message = "failed", strings.ReplaceAll(result.Err.Error(), "\n", " ")
+		case result.Skipped:
+			status = "skipped"
+		}
 
-func generatePixiMetadata(versionFetcher versionology.VersionFetcher) ([]versionology.Dependency, error) {
-	version := versionFetcher.Version().String()
-	pixiRelease, ok := versionFetcher.(GitHubRelease)
-	if !ok {
-		return nil, errors.New("expected a GitHubRelease")
-	}
+		versions := map[string]bool{}
+		for _, dependency := range result.Dependencies {
+			versions[dependency.Version] = true
+		}
 
-	fmt.Printf("version: %s\n", version)
-
-	var licenseIDsAsInterface []interface{}
-	licenseIDsAsInterface = append(licenseIDsAsInterface, "BSD-3-Clause")
-	configMetadataDependency := cargo.ConfigMetadataDependency{
-		CPE:            fmt.Sprintf("cpe:2.3:a:pixi:pixi:%s:*:*:*:*:python:*:*", version),
-		Checksum:       pixiRelease.BinarySHA256,
-		ID:             "pixi",
-		Licenses:       licenseIDsAsInterface,
-		Name:           "pixi",
-		OS:             "linux",
-		Arch:           pixiRelease.Arch,
-		PURL:           retrieve.GeneratePURL("pixi", version, pixiRelease.SourceSHA256, pixiRelease.SourceURL),
-		Source:         pixiRelease.SourceURL,
-		SourceChecksum: pixiRelease.SourceSHA256,
-		Stacks:         []string{"*"},
-		URI:            pixiRelease.BinaryURL,
-		Version:        version,
+		fmt.Fprintf(table, "%s\t%s\t%d\t%s\n", result.ID, status, len(versions), message)
 	}
 
-	return []versionology.Dependency{{
-		ConfigMetadataDependency: configMetadataDependency,
-		SemverVersion:            versionFetcher.Version(),
-	}}, nil
-}
-
-// Taken from libdependency.retrieve.retrieval
-// https://github.com/joshuatcasey/libdependency/blob/main/retrieve/retrieval.go
-func toWorkflowJson(item any) (string, error) {
-	if bytes, err := json.Marshal(item); err != nil {
-		return "", err
-	} else {
-		return string(bytes), nil
-	}
+	return table.Flush()
 }
 
-func main() {
+func run() error {
 	buildpackTomlPathUsage := "full path to the buildpack.toml file, using only one of camelCase, snake_case, or dash_case"
 	var buildpackTomlPath string
 	var output string
+	var configPath string
+	var failFast bool
+	var verify bool
 
 	flag.StringVar(&buildpackTomlPath, "buildpack_toml_path", buildpackTomlPath, buildpackTomlPathUsage)
 	flag.StringVar(&output, "output", "", "filename for the output JSON metadata")
+	flag.StringVar(&configPath, "config", "", "path to the configuration of the dependencies, defaults to the embedded sources.toml")
+	flag.BoolVar(&failFast, "fail-fast", false, "stop at the first dependency failing to be retrieved")
+	flag.BoolVar(&verify, "verify", false, "download the new artifacts to verify their checksum and content")
 	flag.Parse()
 
 	exists, err := fs.Exists(buildpackTomlPath)
-
 	if err != nil {
-		panic(err)
+		return err
 	} else if !exists {
-		panic(fmt.Errorf("could not locate buildpack.toml at '%s'", buildpackTomlPath))
+		return fmt.Errorf("could not locate buildpack.toml at '%s'", buildpackTomlPath)
 	}
 
 	if output == "" {
-		panic("output is required")
+		return errors.New("output is required")
 	}
 
-	config, err := buildpack_config.ParseBuildpackToml(buildpackTomlPath)
+	configContent := defaultConfig
+	if configPath != "" {
+		configContent, err = os.ReadFile(configPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	dependenciesConfig, err := sources.ParseConfig(bytes.NewReader(configContent))
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	metadataGeneratorMap := map[string]retrieve.GenerateMetadataFunc{
-		"pip":        generatePipMetadata,
-		"pipenv":     generatePipenvMetadata,
-		"poetry":     generatePoetryMetadata,
-		"miniconda3": generateMinicondaMetadata,
-		"uv":         generateUvMetadata,
-		"pixi":       generatePixiMetadata,
+	config, err := buildpack_config.ParseBuildpackToml(buildpackTomlPath)
+	if err != nil {
+		return err
 	}
 
-	var dependencies []versionology.Dependency
+	upstream := sources.DefaultUpstream()
+	verifier := NewVerifier(upstream.Client)
 
-	for id, generateMetadata := range metadataGeneratorMap {
-		newVersions, err := retrieve.GetNewVersionsForId(id, config, getAllVersionsForInstaller(id))
-		if err != nil {
-			panic(err)
+	results := retrieveAll(dependenciesConfig.Dependencies, func(dependency sources.Dependency) ([]RetrievedDependency, error) {
+		dependencies, err := retrieveDependency(dependency, config, upstream)
+		if err != nil || !verify {
+			return dependencies, err
 		}
 
-		// This loop is taken from GenerateAllMetadata
-		// https://github.com/joshuatcasey/libdependency/blob/main/retrieve/retrieval.go
-		for _, version := range newVersions {
-			metadata, err := generateMetadata(version)
-			if err != nil {
-				panic(err)
-			}
-
-			var targets []string
-			for _, metadatum := range metadata {
-				targets = append(targets, metadatum.Target)
-			}
-			fmt.Printf("Generating metadata for %s, with targets [%s]\n", version.Version().String(), strings.Join(targets, ", "))
-			dependencies = append(dependencies, metadata...)
+		if err := verifier.Verify(dependencies, dependency.Executable); err != nil {
+			return nil, err
 		}
+		return dependencies, nil
+	}, failFast)
+
+	// The dependencies retrieved successfully are written even when others
+	// failed
+	dependencies := []RetrievedDependency{}
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil || result.Skipped {
+			failed = append(failed, result.ID)
+			continue
+		}
+		dependencies = append(dependencies, result.Dependencies...)
 	}
 
 	metadataJson, err := toWorkflowJson(dependencies)
 	if err != nil {
-		panic(fmt.Errorf("unable to marshall metadata json, with error=%w", err))
+		return fmt.Errorf("unable to marshall metadata json, with error=%w", err)
 	}
 
 	if err = os.WriteFile(output, []byte(metadataJson), os.ModePerm); err != nil {
-		panic(fmt.Errorf("cannot write to %s: %w", output, err))
+		return fmt.Errorf("cannot write to %s: %w", output, err)
+	}
+	fmt.Printf("Wrote metadata to %s\n", output)
+
+	fmt.Println()
+	err = writeSummary(os.Stdout, results)
+	if err != nil {
+		return err
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to retrieve %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func main() {
+	var err error
+	if len(os.Args) > 1 && os.Args[1] == "update" {
+		err = runUpdate(os.Args[2:])
 	} else {
-		fmt.Printf("Wrote metadata to %s\n", output)
+		err = run()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
 	}
 }
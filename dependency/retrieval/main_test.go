@@ -0,0 +1,321 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/joshuatcasey/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/sources"
+)
+
+func TestGetAllVersions(t *testing.T) {
+	content, err := os.ReadFile("sources/testdata/pypi_poetry.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pypi/poetry/json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	upstream := sources.Upstream{Client: server.Client(), PyPIURL: server.URL + "/pypi"}
+	dependency := sources.Dependency{ID: "poetry", Source: sources.Source{Type: sources.PyPIType, Project: "poetry"}}
+
+	versions, err := getAllVersions(dependency, nil, upstream)()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(versions) != 2 {
+		t.Errorf("expected 2 versions, got %d", len(versions))
+	}
+}
+
+func TestPostRelease(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"releases": {"2024.0.1.post1": [{
+			"packagetype": "sdist",
+			"url": "https://example.com/pipenv-2024.0.1.post1.tar.gz",
+			"upload_time_iso_8601": "2024-06-24T14:33:14.000000Z",
+			"digests": {"sha256": "aaaa"}
+		}]}}`))
+	}))
+	defer server.Close()
+
+	upstream := sources.Upstream{Client: server.Client(), PyPIURL: server.URL}
+	dependency := sources.Dependency{
+		ID:       "pipenv",
+		Name:     "Pipenv",
+		CPE:      "cpe:2.3:a:pypa:pipenv:{version}:*:*:*:*:python:*:*",
+		Licenses: []string{"MIT"},
+		Source:   sources.Source{Type: sources.PyPIType, Project: "pipenv"},
+	}
+
+	versions, err := getAllVersions(dependency, nil, upstream)()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("expected 1 version, got %d", len(versions))
+	}
+
+	metadata, err := generateMetadata(dependency)(versions[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// buildpack.toml versions are semver, pip needs the PEP 440 one
+	pipenv := metadata[0]
+	if pipenv.Version != "2024.0.1+post1" {
+		t.Errorf("unexpected version %s", pipenv.Version)
+	}
+	if pipenv.CPE != "cpe:2.3:a:pypa:pipenv:2024.0.1.post1:*:*:*:*:python:*:*" {
+		t.Errorf("unexpected CPE %s", pipenv.CPE)
+	}
+	if !strings.Contains(pipenv.PURL, "/pipenv@2024.0.1.post1?") {
+		t.Errorf("unexpected PURL %s", pipenv.PURL)
+	}
+}
+
+func TestDependencyConstraints(t *testing.T) {
+	var config cargo.Config
+	config.Metadata.DependencyConstraints = []cargo.ConfigMetadataDependencyConstraint{
+		{ID: "uv", Constraint: "0.11.*", Patches: 2},
+		{ID: "pixi", Constraint: "*", Patches: 4},
+		{ID: "uv", Constraint: "0.10.*", Patches: 1},
+	}
+
+	constraints := dependencyConstraints(config, "uv")
+	expected := []sources.Constraint{
+		{Constraint: "0.11.*", Patches: 2},
+		{Constraint: "0.10.*", Patches: 1},
+	}
+	if !reflect.DeepEqual(constraints, expected) {
+		t.Errorf("expected %v, got %v", expected, constraints)
+	}
+}
+
+func TestGenerateMetadata(t *testing.T) {
+	uploadTime := time.Date(2026, 3, 10, 18, 21, 0, 0, time.UTC)
+
+	t.Run("binary release", func(t *testing.T) {
+		dependency := sources.Dependency{
+			ID:       "uv",
+			Name:     "uv",
+			CPE:      "cpe:2.3:a:uv:uv:{version}:*:*:*:*:python:*:*",
+			Licenses: []string{"Apache-2.0", "MIT"},
+		}
+
+		metadata, err := generateMetadata(dependency)(sources.Release{
+			SemverVersion: semver.MustParse("0.10.11"),
+			Arch:          "arm64",
+			BinaryURL:     "https://example.com/uv-aarch64.tar.gz",
+			BinarySHA256:  strings.Repeat("b", 64),
+			SourceURL:     "https://example.com/source.tar.gz",
+			SourceSHA256:  strings.Repeat("a", 64),
+			UploadTime:    uploadTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(metadata) != 1 {
+			t.Fatalf("expected 1 dependency, got %d", len(metadata))
+		}
+
+		uv := metadata[0]
+		if uv.CPE != "cpe:2.3:a:uv:uv:0.10.11:*:*:*:*:python:*:*" {
+			t.Errorf("unexpected CPE %s", uv.CPE)
+		}
+		if uv.URI != "https://example.com/uv-aarch64.tar.gz" || uv.Checksum != "sha256:"+strings.Repeat("b", 64) {
+			t.Errorf("expected the binary as artifact, got %s %s", uv.URI, uv.Checksum)
+		}
+		if uv.SourceChecksum != "sha256:"+strings.Repeat("a", 64) {
+			t.Errorf("unexpected source checksum %s", uv.SourceChecksum)
+		}
+		if uv.OS != "linux" || uv.Arch != "arm64" {
+			t.Errorf("unexpected platform %s/%s", uv.OS, uv.Arch)
+		}
+		if len(uv.Licenses) != 2 {
+			t.Errorf("expected the configured licenses, got %v", uv.Licenses)
+		}
+		if uv.DeprecationDate != nil {
+			t.Errorf("expected no deprecation date without a published end of life, got %v", uv.DeprecationDate)
+		}
+	})
+
+	t.Run("source release used as published", func(t *testing.T) {
+		dependency := sources.Dependency{ID: "poetry", Name: "Poetry", Licenses: []string{"MIT"}}
+
+		metadata, err := generateMetadata(dependency)(sources.Release{
+			SemverVersion: semver.MustParse("2.3.2"),
+			SourceURL:     "https://example.com/poetry-2.3.2.tar.gz",
+			SourceSHA256:  strings.Repeat("a", 64),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		poetry := metadata[0]
+		if poetry.URI != "https://example.com/poetry-2.3.2.tar.gz" || poetry.Checksum != "sha256:"+strings.Repeat("a", 64) {
+			t.Errorf("expected the source as artifact, got %s %s", poetry.URI, poetry.Checksum)
+		}
+		if poetry.DeprecationDate != nil {
+			t.Errorf("expected no deprecation date, got %v", poetry.DeprecationDate)
+		}
+	})
+
+	t.Run("source release compiled by the workflow", func(t *testing.T) {
+		dependency := sources.Dependency{ID: "pip", Name: "Pip", Licenses: []string{"MIT"}, Target: "noarch"}
+
+		metadata, err := generateMetadata(dependency)(sources.Release{
+			SemverVersion: semver.MustParse("26.0.1"),
+			SourceURL:     "https://example.com/pip-26.0.1.tar.gz",
+			SourceSHA256:  strings.Repeat("a", 64),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, pip := range metadata {
+			if pip.URI != "" || pip.Checksum != "" {
+				t.Errorf("expected no artifact before compilation, got %s %s", pip.URI, pip.Checksum)
+			}
+			if pip.Target != "noarch" {
+				t.Errorf("unexpected target %s", pip.Target)
+			}
+		}
+	})
+
+	t.Run("wheelhouses", func(t *testing.T) {
+		dependency := sources.Dependency{
+			ID:       "poetry",
+			Name:     "Poetry",
+			Licenses: []string{"MIT"},
+			Target:   sources.WheelhouseTarget,
+			Pythons:  []string{"3.9", "3.10", "3.11"},
+		}
+
+		metadata, err := generateMetadata(dependency)(sources.Release{
+			SemverVersion:  semver.MustParse("2.3.2"),
+			SourceURL:      "https://example.com/poetry-2.3.2.tar.gz",
+			SourceSHA256:   strings.Repeat("a", 64),
+			RequiresPython: "<4.0,>=3.10",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		poetry := metadata[0]
+		if poetry.ID != "poetry" || poetry.Target != "" {
+			t.Errorf("expected the source distribution first, got %s %s", poetry.ID, poetry.Target)
+		}
+		if poetry.URI != "https://example.com/poetry-2.3.2.tar.gz" || poetry.Checksum != "sha256:"+strings.Repeat("a", 64) {
+			t.Errorf("expected the source as artifact, got %s %s", poetry.URI, poetry.Checksum)
+		}
+
+		var got []string
+		for _, wheelhouse := range metadata[1:] {
+			got = append(got, wheelhouse.ID+"/"+wheelhouse.Target+"/"+wheelhouse.OS+"/"+wheelhouse.Arch)
+			if wheelhouse.URI != "" || wheelhouse.Checksum != "" {
+				t.Errorf("expected no artifact before compilation, got %s %s", wheelhouse.URI, wheelhouse.Checksum)
+			}
+			if wheelhouse.Source != "https://example.com/poetry-2.3.2.tar.gz" {
+				t.Errorf("unexpected source %s", wheelhouse.Source)
+			}
+		}
+
+		expected := []string{
+			"poetry-cp310/wheelhouse-cp310/linux/amd64",
+			"poetry-cp310/wheelhouse-cp310/linux/arm64",
+			"poetry-cp311/wheelhouse-cp311/linux/amd64",
+			"poetry-cp311/wheelhouse-cp311/linux/arm64",
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("unexpected wheelhouses %v", got)
+		}
+	})
+}
+
+func TestRetrieveAll(t *testing.T) {
+	dependencies := []sources.Dependency{{ID: "pip"}, {ID: "uv"}, {ID: "pixi"}}
+
+	retrieveFunc := func(dependency sources.Dependency) ([]RetrievedDependency, error) {
+		if dependency.ID == "uv" {
+			return nil, errors.New("rate limited")
+		}
+		return []RetrievedDependency{{}}, nil
+	}
+
+	t.Run("carries on after a failure", func(t *testing.T) {
+		results := retrieveAll(dependencies, retrieveFunc, false)
+
+		if len(results) != 3 {
+			t.Fatalf("expected 3 results, got %d", len(results))
+		}
+		if results[1].Err == nil || results[2].Err != nil || len(results[2].Dependencies) != 1 {
+			t.Errorf("unexpected results %v", results)
+		}
+	})
+
+	t.Run("stops at the first failure with fail fast", func(t *testing.T) {
+		results := retrieveAll(dependencies, retrieveFunc, true)
+
+		if len(results) != 3 {
+			t.Fatalf("expected 3 results, got %d", len(results))
+		}
+		if results[1].Err == nil || !results[2].Skipped || results[2].Dependencies != nil {
+			t.Errorf("unexpected results %v", results)
+		}
+	})
+}
+
+func TestWriteSummary(t *testing.T) {
+	pip := versionology.Dependency{}
+	pip.Version = "26.0.1"
+
+	buffer := bytes.NewBuffer(nil)
+	err := writeSummary(buffer, []Result{
+		{ID: "pip", Dependencies: []RetrievedDependency{{Dependency: pip}, {Dependency: pip}}},
+		{ID: "uv", Err: errors.New("rate\nlimited")},
+		{ID: "pixi", Skipped: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		lines = append(lines, strings.TrimRight(line, " "))
+	}
+
+	expected := []string{
+		"ID    STATUS   VERSIONS  ERROR",
+		"pip   ok       1",
+		"uv    failed   0         rate limited",
+		"pixi  skipped  0",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected summary:\n%s", buffer.String())
+	}
+}
@@ -0,0 +1,184 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nfx/go-htmltable"
+)
+
+// DefaultAnacondaLimit is the number of installers considered when no limit
+// is configured. Each of them requires a request to GitHub for the SHA-256
+// of its sources, which gets throttled when there are too many.
+const DefaultAnacondaLimit = 8
+
+// AnacondaEntry is a row of an Anaconda HTML index.
+type AnacondaEntry struct {
+	Filename     string `header:"Filename"`
+	Size         int    `header:"Size"`
+	LastModified string `header:"Last Modified"`
+	SHA256       string `header:"SHA256"`
+}
+
+// AnacondaIndex lists the installers of an Anaconda HTML index, e.g.
+// https://repo.anaconda.com/miniconda.
+type AnacondaIndex struct {
+	client    *http.Client
+	url       string
+	pattern   *regexp.Regexp
+	sourceURL string
+	limit     int
+}
+
+// NewAnacondaIndex returns the source listing the installers of the index at
+// url matching pattern, see Source.Pattern. Both the index and the sources
+// are reached with the client of upstream.
+func NewAnacondaIndex(upstream Upstream, url, pattern, sourceURL string, limit int) (AnacondaIndex, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return AnacondaIndex{}, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+
+	if re.NumSubexp() < 2 {
+		return AnacondaIndex{}, fmt.Errorf("pattern %q must capture the version and the architecture", pattern)
+	}
+
+	if limit <= 0 {
+		limit = DefaultAnacondaLimit
+	}
+
+	return AnacondaIndex{
+		client:    upstream.Client,
+		url:       url,
+		pattern:   re,
+		sourceURL: sourceURL,
+		limit:     limit,
+	}, nil
+}
+
+// Releases returns a Release per version and architecture of the most recent
+// installers. Of the builds of a version, only the most recent one is kept.
+func (source AnacondaIndex) Releases() ([]Release, error) {
+	response, err := source.client.Get(source.url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not list the installers: %s returned %s", source.url, response.Status)
+	}
+
+	entries, err := htmltable.NewSliceFromResponse[AnacondaEntry](response)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []AnacondaEntry
+	for _, entry := range entries {
+		if source.pattern.MatchString(entry.Filename) {
+			matches = append(matches, entry)
+		}
+	}
+
+	if len(matches) > source.limit {
+		matches = matches[:source.limit]
+	}
+
+	builds := map[string]int{}
+	releases := map[string]Release{}
+
+	for _, entry := range matches {
+		groups := source.pattern.FindStringSubmatch(entry.Filename)
+		fullVersion, suffix, arch := groups[1], "", groups[len(groups)-1]
+		if len(groups) > 3 {
+			suffix = groups[2]
+		}
+
+		originalVersion := strings.TrimSuffix(fullVersion, suffix)
+		version, err := semver.NewVersion(originalVersion)
+		if err != nil {
+			continue
+		}
+
+		build := 0
+		if suffix != "" {
+			build, err = strconv.Atoi(strings.TrimPrefix(suffix, "-"))
+			if err != nil {
+				return nil, fmt.Errorf("could not parse build number of %s: %w", entry.Filename, err)
+			}
+		}
+
+		key := fmt.Sprintf("%s-%s", version, arch)
+		if known, ok := builds[key]; ok && known > build {
+			fmt.Println("skip", fullVersion, "as a more recent build of", version, "was already found")
+			continue
+		}
+
+		uploadTime, err := time.Parse(time.DateTime, entry.LastModified)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse upload time '%s' as date for version %s: %w", entry.LastModified, fullVersion, err)
+		}
+
+		sourceURL := Expand(source.sourceURL, originalVersion, arch)
+		sourceSHA256, err := source.sha256(sourceURL + ".sha256sum")
+		if err != nil {
+			return nil, err
+		}
+
+		builds[key] = build
+		releases[key] = Release{
+			SemverVersion: version,
+			Arch:          ArchMap[arch],
+			BinaryURL:     fmt.Sprintf("%s/%s", source.url, entry.Filename),
+			BinarySHA256:  entry.SHA256,
+			SourceURL:     sourceURL,
+			SourceSHA256:  sourceSHA256,
+			UploadTime:    uploadTime,
+		}
+	}
+
+	var result []Release
+	for _, release := range releases {
+		result = append(result, release)
+	}
+	return result, nil
+}
+
+func (source AnacondaIndex) sha256(url string) (string, error) {
+	response, err := source.client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not retrieve the source sha256: %s returned %s", url, response.Status)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read body of sha256 request")
+	}
+
+	// The file may follow the sha256sum format, `<sha256>  <filename>`
+	fields := bytes.Fields(content)
+	if len(fields) == 0 || len(fields[0]) != 2*sha256.Size {
+		return "", fmt.Errorf("source sha256 from %s does not have the correct size", url)
+	}
+
+	return string(fields[0]), nil
+}
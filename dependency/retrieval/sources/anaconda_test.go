@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/sources"
+)
+
+const minicondaPattern = `Miniconda3-py39_(\d+.\d+.\d+(-\d+)?)-Linux-(x86_64|aarch64)`
+
+func TestAnacondaIndexReleases(t *testing.T) {
+	upstream, server := newUpstream(t, map[string]string{
+		"/miniconda/": "miniconda.html",
+		"/conda/releases/download/25.9.1/conda-25.9.1.tar.gz.sha256sum": strings.Repeat("9", 64) + "  conda-25.9.1.tar.gz\n",
+		"/conda/releases/download/25.7.0/conda-25.7.0.tar.gz.sha256sum": strings.Repeat("7", 64),
+	})
+
+	source, err := sources.NewAnacondaIndex(upstream, server.URL+"/miniconda/", minicondaPattern, server.URL+"/conda/releases/download/{version}/conda-{version}.tar.gz", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releases, err := source.Releases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sortReleases(releases)
+
+	var got []string
+	for _, release := range releases {
+		got = append(got, release.Version().String()+"/"+release.Arch)
+	}
+	if strings.Join(got, " ") != "25.7.0/amd64 25.9.1/amd64 25.9.1/arm64" {
+		t.Fatalf("unexpected releases %v", got)
+	}
+
+	// The build 1 of 25.9.1 is superseded by the build 3
+	release := releases[1]
+	if !strings.HasSuffix(release.BinaryURL, "/Miniconda3-py39_25.9.1-3-Linux-x86_64.sh") {
+		t.Errorf("expected the most recent build, got %s", release.BinaryURL)
+	}
+	if release.BinarySHA256 != strings.Repeat("3", 64) {
+		t.Errorf("unexpected binary sha256 %s", release.BinarySHA256)
+	}
+	if release.SourceURL != server.URL+"/conda/releases/download/25.9.1/conda-25.9.1.tar.gz" {
+		t.Errorf("unexpected source URL %s", release.SourceURL)
+	}
+	if release.SourceSHA256 != strings.Repeat("9", 64) {
+		t.Errorf("unexpected source sha256 %s", release.SourceSHA256)
+	}
+	if !release.UploadTime.Equal(time.Date(2025, 10, 20, 15, 1, 24, 0, time.UTC)) {
+		t.Errorf("unexpected upload time %s", release.UploadTime)
+	}
+}
+
+func TestAnacondaIndexLimit(t *testing.T) {
+	upstream, server := newUpstream(t, map[string]string{
+		"/miniconda/": "miniconda.html",
+		"/conda/releases/download/25.9.1/conda-25.9.1.tar.gz.sha256sum": strings.Repeat("9", 64),
+	})
+
+	source, err := sources.NewAnacondaIndex(upstream, server.URL+"/miniconda/", minicondaPattern, server.URL+"/conda/releases/download/{version}/conda-{version}.tar.gz", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releases, err := source.Releases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(releases) != 2 {
+		t.Errorf("expected 2 releases, got %d", len(releases))
+	}
+}
+
+func TestAnacondaIndexFailures(t *testing.T) {
+	for _, tc := range []struct {
+		name, sha256sum, message string
+	}{
+		{"too short checksum", strings.Repeat("9", 63), "does not have the correct size"},
+		{"too long checksum", strings.Repeat("9", 65), "does not have the correct size"},
+		{"empty checksum", "", "does not have the correct size"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			upstream, server := newUpstream(t, map[string]string{
+				"/miniconda/": "miniconda.html",
+				"/conda/releases/download/25.9.1/conda-25.9.1.tar.gz.sha256sum": tc.sha256sum,
+			})
+
+			source, err := sources.NewAnacondaIndex(upstream, server.URL+"/miniconda/", minicondaPattern, server.URL+"/conda/releases/download/{version}/conda-{version}.tar.gz", 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = source.Releases()
+			if err == nil || !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("expected %q, got %v", tc.message, err)
+			}
+		})
+	}
+
+	t.Run("missing checksum", func(t *testing.T) {
+		upstream, server := newUpstream(t, map[string]string{
+			"/miniconda/": "miniconda.html",
+		})
+
+		source, err := sources.NewAnacondaIndex(upstream, server.URL+"/miniconda/", minicondaPattern, server.URL+"/conda/releases/download/{version}/conda-{version}.tar.gz", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = source.Releases()
+		if err == nil || !strings.Contains(err.Error(), "404 Not Found") {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+	})
+
+	t.Run("pattern without architecture", func(t *testing.T) {
+		_, err := sources.NewAnacondaIndex(sources.Upstream{}, "", `Miniconda3-(\d+)`, "", 0)
+		if err == nil || !strings.Contains(err.Error(), "must capture the version and the architecture") {
+			t.Errorf("expected a pattern error, got %v", err)
+		}
+	})
+}
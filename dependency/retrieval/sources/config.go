@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/pep440"
+)
+
+// Config describes the dependencies to retrieve.
+type Config struct {
+	Dependencies []Dependency `toml:"dependencies"`
+}
+
+// Dependency describes how to retrieve a dependency and generate its
+// buildpack.toml metadata.
+type Dependency struct {
+	// ID is the id of the dependency in buildpack.toml.
+	ID string `toml:"id"`
+
+	// Name is the name of the dependency in buildpack.toml.
+	Name string `toml:"name"`
+
+	// CPE is the CPE of the dependency, with a {version} placeholder.
+	CPE string `toml:"cpe"`
+
+	// Licenses of the dependency. When empty, they are looked up in the
+	// source of each version.
+	Licenses []string `toml:"licenses"`
+
+	// Executable is the file the binary archives of the dependency must
+	// hold, checked by --verify.
+	Executable string `toml:"executable"`
+
+	// Target is the target the dependency is compiled for by the update
+	// workflow. Dependencies without target are used as published.
+	Target string `toml:"target"`
+
+	// Pythons are the python versions, e.g. 3.12, the wheelhouses of a
+	// dependency with the WheelhouseTarget are compiled for.
+	Pythons []string `toml:"pythons"`
+
+	// Source describes where the releases are published.
+	Source Source `toml:"source"`
+}
+
+// WheelhouseTarget is the target of the python tools compiled into a
+// wheelhouse: the wheels of the tool and of its dependencies, to install it
+// without network access. A wheelhouse is compiled per version of python and
+// architecture, see WheelhouseTargets.
+const WheelhouseTarget = "wheelhouse"
+
+// WheelhouseTargets returns the target of the wheelhouses of each of the
+// pythons of dependency satisfying requiresPython, named after the python
+// ABI, e.g. wheelhouse-cp312.
+func (dependency Dependency) WheelhouseTargets(requiresPython string) ([]string, error) {
+	var targets []string
+	for _, python := range dependency.Pythons {
+		version, err := pep440.Parse(python)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(version.Release) != 2 {
+			return nil, fmt.Errorf("invalid python %q, expected major.minor", python)
+		}
+
+		satisfied, err := version.Satisfies(requiresPython)
+		if err != nil {
+			return nil, fmt.Errorf("invalid requires-python of %s: %w", dependency.ID, err)
+		}
+
+		if satisfied {
+			targets = append(targets, fmt.Sprintf("%s-cp%d%d", WheelhouseTarget, version.Release[0], version.Release[1]))
+		}
+	}
+	return targets, nil
+}
+
+// Source describes the upstream of a dependency.
+type Source struct {
+	// Type is one of PyPIType, GitHubType or AnacondaType.
+	Type string `toml:"type"`
+
+	// Project is the PyPI project or the org/repository of the GitHub
+	// project.
+	Project string `toml:"project"`
+
+	// Prereleases includes the prereleases of the PyPI project, which are
+	// left out by default.
+	Prereleases bool `toml:"prereleases"`
+
+	// Asset is the name of the GitHub release asset with an {arch}
+	// placeholder.
+	Asset string `toml:"asset"`
+
+	// SourceAsset is the name of the GitHub release asset holding the
+	// sources.
+	SourceAsset string `toml:"source-asset"`
+
+	// URL is the URL of the Anaconda index.
+	URL string `toml:"url"`
+
+	// Pattern matches the installers of the Anaconda index. Its first group
+	// is the version, its optional second group the build number suffix of
+	// the version and its last group the architecture.
+	Pattern string `toml:"pattern"`
+
+	// SourceURL is the URL of the sources of the Anaconda installers, with
+	// a {version} placeholder. Its SHA-256 is read from SourceURL.sha256sum.
+	SourceURL string `toml:"source-url"`
+
+	// Limit bounds the number of GitHub releases inspected or Anaconda
+	// installers considered.
+	Limit int `toml:"limit"`
+}
+
+// ParseConfig parses the TOML configuration read from reader.
+func ParseConfig(reader io.Reader) (Config, error) {
+	var config Config
+	_, err := toml.NewDecoder(reader).Decode(&config)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	for _, dependency := range config.Dependencies {
+		if dependency.ID == "" {
+			return Config{}, fmt.Errorf("dependency without id in configuration")
+		}
+
+		if dependency.Target == WheelhouseTarget {
+			if len(dependency.Pythons) == 0 {
+				return Config{}, fmt.Errorf("wheelhouse dependency %s without pythons", dependency.ID)
+			}
+			if _, err := dependency.WheelhouseTargets(""); err != nil {
+				return Config{}, fmt.Errorf("invalid pythons for %s: %w", dependency.ID, err)
+			}
+		}
+
+		_, err := NewSource(dependency.Source, DefaultUpstream(), nil)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid source for %s: %w", dependency.ID, err)
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/sources"
+)
+
+func TestParseConfig(t *testing.T) {
+	file, err := os.Open("../sources.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	config, err := sources.ParseConfig(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, dependency := range config.Dependencies {
+		ids = append(ids, dependency.ID)
+	}
+	if strings.Join(ids, " ") != "pip pipenv poetry miniconda3 uv pixi" {
+		t.Errorf("unexpected dependencies %v", ids)
+	}
+
+	for _, tc := range []struct {
+		name, content, message string
+	}{
+		{"malformed", "%%%", "failed to parse configuration"},
+		{"missing id", "[[dependencies]]\n[dependencies.source]\ntype = \"pypi\"", "dependency without id"},
+		{"unknown type", "[[dependencies]]\nid = \"tool\"\n[dependencies.source]\ntype = \"ftp\"", `invalid source for tool: unknown source type "ftp"`},
+		{"wheelhouse without pythons", "[[dependencies]]\nid = \"tool\"\ntarget = \"wheelhouse\"\n[dependencies.source]\ntype = \"pypi\"", "wheelhouse dependency tool without pythons"},
+		{"invalid python", "[[dependencies]]\nid = \"tool\"\ntarget = \"wheelhouse\"\npythons = [\"3\"]\n[dependencies.source]\ntype = \"pypi\"", `invalid python "3", expected major.minor`},
+		{"invalid github project", "[[dependencies]]\nid = \"tool\"\n[dependencies.source]\ntype = \"github\"\nproject = \"tool\"", `invalid github project "tool"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := sources.ParseConfig(strings.NewReader(tc.content))
+			if err == nil || !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("expected %q, got %v", tc.message, err)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	if got := sources.Expand("uv-{arch}-{version}.tar.gz", "0.10.11", "x86_64"); got != "uv-x86_64-0.10.11.tar.gz" {
+		t.Errorf("unexpected expansion %s", got)
+	}
+}
+
+func TestWheelhouseTargets(t *testing.T) {
+	dependency := sources.Dependency{ID: "pipenv", Pythons: []string{"3.9", "3.12", "3.14"}}
+
+	targets, err := dependency.WheelhouseTargets(">=3.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(targets, " ") != "wheelhouse-cp312 wheelhouse-cp314" {
+		t.Errorf("unexpected targets %v", targets)
+	}
+
+	if _, err := dependency.WheelhouseTargets(">=three"); err == nil || !strings.Contains(err.Error(), "invalid requires-python of pipenv") {
+		t.Errorf("expected a requires-python error, got %v", err)
+	}
+}
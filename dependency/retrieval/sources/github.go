@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-github/v81/github"
+)
+
+const (
+	// DefaultSourceAsset is the name of the GitHub release asset holding the
+	// sources when none is configured.
+	DefaultSourceAsset = "source.tar.gz"
+
+	// DefaultGitHubLimit is the maximum number of GitHub releases inspected
+	// when no limit is configured.
+	DefaultGitHubLimit = 100
+
+	// GitHubPageSize is the number of releases requested per page.
+	GitHubPageSize = 30
+
+	// GitHubRetries is the number of times a request hitting the rate limit
+	// is retried.
+	GitHubRetries = 3
+
+	// MaxRateLimitWait is the longest wait for a rate limit reset, beyond
+	// which the request fails rather than blocking the retrieval.
+	MaxRateLimitWait = 5 * time.Minute
+)
+
+// Constraint is a dependency constraint of buildpack.toml: the number of
+// most recent versions matching Constraint to keep.
+type Constraint struct {
+	Constraint string
+	Patches    int
+}
+
+// GitHubReleases lists the binaries attached to the releases of a GitHub
+// project.
+type GitHubReleases struct {
+	client      *github.Client
+	sleep       func(time.Duration)
+	org         string
+	repository  string
+	asset       string
+	sourceAsset string
+	limit       int
+	constraints []Constraint
+}
+
+// NewGitHubReleases returns the source listing the asset, with an {arch}
+// placeholder, of the releases of org/repository on the GitHub of upstream.
+// The sources of the release are taken from sourceAsset. Releases are listed
+// until each of constraints is satisfied or limit releases were inspected.
+func NewGitHubReleases(upstream Upstream, org, repository, asset, sourceAsset string, limit int, constraints []Constraint) (GitHubReleases, error) {
+	baseURL, err := url.Parse(upstream.GitHubAPIURL)
+	if err != nil {
+		return GitHubReleases{}, fmt.Errorf("invalid GitHub API URL %q: %w", upstream.GitHubAPIURL, err)
+	}
+	if !strings.HasSuffix(baseURL.Path, "/") {
+		baseURL.Path += "/"
+	}
+
+	client := github.NewClient(upstream.Client)
+	client.BaseURL = baseURL
+	if upstream.GitHubToken != "" {
+		client = client.WithAuthToken(upstream.GitHubToken)
+	}
+
+	sleep := upstream.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	if sourceAsset == "" {
+		sourceAsset = DefaultSourceAsset
+	}
+
+	if limit <= 0 {
+		limit = DefaultGitHubLimit
+	}
+
+	for _, constraint := range constraints {
+		if _, err := semver.NewConstraint(constraint.Constraint); err != nil {
+			return GitHubReleases{}, fmt.Errorf("invalid constraint %q: %w", constraint.Constraint, err)
+		}
+	}
+
+	return GitHubReleases{
+		client:      client,
+		sleep:       sleep,
+		org:         org,
+		repository:  repository,
+		asset:       asset,
+		sourceAsset: sourceAsset,
+		limit:       limit,
+		constraints: constraints,
+	}, nil
+}
+
+// Releases returns a Release per release and architecture of the most recent
+// GitHub releases. Drafts, prereleases and releases whose tag is not a
+// version are skipped.
+func (source GitHubReleases) Releases() ([]Release, error) {
+	var result []Release
+	var versions []*semver.Version
+
+	opt := &github.ListOptions{Page: 1, PerPage: min(GitHubPageSize, source.limit)}
+	inspected := 0
+	for {
+		releases, response, err := source.listReleases(opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, release := range releases {
+			if inspected == source.limit {
+				break
+			}
+			inspected++
+
+			if release.GetDraft() || release.GetPrerelease() {
+				continue
+			}
+
+			version, err := semver.NewVersion(release.GetTagName())
+			if err != nil || version.Prerelease() != "" {
+				fmt.Printf("skip %s/%s release %q, its tag is not a version\n", source.org, source.repository, release.GetTagName())
+				continue
+			}
+
+			var sourceURL, sourceSHA256 string
+			for _, asset := range release.Assets {
+				if asset.GetName() == source.sourceAsset {
+					sourceURL = asset.GetBrowserDownloadURL()
+					sourceSHA256 = digest(asset)
+					break
+				}
+			}
+			if sourceURL == "" || sourceSHA256 == "" {
+				return nil, fmt.Errorf("Failed to find source asset of %s", version)
+			}
+
+			versions = append(versions, version)
+
+			for inArch, outArch := range ArchMap {
+				assetName := Expand(source.asset, version.String(), inArch)
+				for _, asset := range release.Assets {
+					if asset.GetName() == assetName {
+						result = append(result, Release{
+							SemverVersion: version,
+							Arch:          outArch,
+							BinaryURL:     asset.GetBrowserDownloadURL(),
+							BinarySHA256:  digest(asset),
+							SourceURL:     sourceURL,
+							SourceSHA256:  sourceSHA256,
+							UploadTime:    asset.GetUpdatedAt().Time,
+						})
+						break
+					}
+				}
+			}
+		}
+
+		if response.NextPage == 0 || inspected == source.limit || source.satisfied(versions) {
+			break
+		}
+		opt.Page = response.NextPage
+	}
+
+	return result, nil
+}
+
+// listReleases lists a page of releases, waiting for the rate limit to reset
+// when it is hit.
+func (source GitHubReleases) listReleases(opt *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	for attempt := 0; ; attempt++ {
+		releases, response, err := source.client.Repositories.ListReleases(context.Background(), source.org, source.repository, opt)
+		if err == nil {
+			return releases, response, nil
+		}
+
+		var wait time.Duration
+		var rateLimitErr *github.RateLimitError
+		var abuseRateLimitErr *github.AbuseRateLimitError
+		var errorResponse *github.ErrorResponse
+		switch {
+		case errors.As(err, &rateLimitErr):
+			wait = time.Until(rateLimitErr.Rate.Reset.Time)
+		case errors.As(err, &abuseRateLimitErr):
+			wait = abuseRateLimitErr.GetRetryAfter()
+		case errors.As(err, &errorResponse) && errorResponse.Response.StatusCode == http.StatusTooManyRequests:
+			wait = retryAfter(errorResponse.Response)
+		default:
+			return nil, nil, err
+		}
+
+		if attempt == GitHubRetries || wait > MaxRateLimitWait {
+			return nil, nil, fmt.Errorf("GitHub rate limit exceeded, set GITHUB_TOKEN to raise it: %w", err)
+		}
+
+		// Back off at least a little longer on each attempt
+		wait = max(wait, time.Duration(attempt+1)*time.Second)
+		fmt.Printf("GitHub rate limit exceeded, retrying in %s\n", wait)
+		source.sleep(wait)
+	}
+}
+
+// retryAfter returns the wait requested by the Retry-After header of
+// response, a minute when there is none.
+func retryAfter(response *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(response.Header.Get("Retry-After"))
+	if err != nil {
+		return time.Minute
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// satisfied returns whether versions hold enough versions for each of the
+// constraints of source. Without constraints, the first page is enough.
+func (source GitHubReleases) satisfied(versions []*semver.Version) bool {
+	for _, constraint := range source.constraints {
+		// Validated by NewGitHubReleases
+		c, _ := semver.NewConstraint(constraint.Constraint)
+
+		matching := 0
+		for _, version := range versions {
+			if c.Check(version) {
+				matching++
+			}
+		}
+		if matching < constraint.Patches {
+			return false
+		}
+	}
+
+	return true
+}
+
+// digest returns the SHA-256 of asset as published by GitHub without its
+// algorithm prefix.
+func digest(asset *github.ReleaseAsset) string {
+	return strings.TrimPrefix(asset.GetDigest(), "sha256:")
+}
@@ -0,0 +1,375 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/sources"
+)
+
+func TestGitHubReleases(t *testing.T) {
+	upstream, _ := newUpstream(t, map[string]string{
+		"/api/repos/astral-sh/uv/releases": "github_uv_releases.json",
+	})
+
+	source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	releases, err := source.Releases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sortReleases(releases)
+
+	var got []string
+	for _, release := range releases {
+		got = append(got, release.Version().String()+"/"+release.Arch)
+	}
+	if strings.Join(got, " ") != "0.10.10/amd64 0.10.11/amd64 0.10.11/arm64" {
+		t.Fatalf("unexpected releases %v", got)
+	}
+
+	release := releases[2]
+	if release.BinaryURL != "https://github.com/astral-sh/uv/releases/download/0.10.11/uv-aarch64-unknown-linux-gnu.tar.gz" {
+		t.Errorf("unexpected binary URL %s", release.BinaryURL)
+	}
+	if release.BinarySHA256 != strings.Repeat("c", 64) {
+		t.Errorf("expected the digest without prefix, got %s", release.BinarySHA256)
+	}
+	if release.SourceURL != "https://github.com/astral-sh/uv/releases/download/0.10.11/source.tar.gz" {
+		t.Errorf("unexpected source URL %s", release.SourceURL)
+	}
+	if release.SourceSHA256 != strings.Repeat("a", 64) {
+		t.Errorf("unexpected source sha256 %s", release.SourceSHA256)
+	}
+}
+
+func TestGitHubReleasesFailures(t *testing.T) {
+	t.Run("missing source asset", func(t *testing.T) {
+		upstream, _ := newUpstream(t, map[string]string{
+			"/api/repos/astral-sh/uv/releases": `[{"tag_name": "0.10.11", "assets": []}]`,
+		})
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = source.Releases()
+		if err == nil || !strings.Contains(err.Error(), "Failed to find source asset") {
+			t.Errorf("expected a missing source error, got %v", err)
+		}
+	})
+
+	t.Run("invalid API URL", func(t *testing.T) {
+		_, err := sources.NewGitHubReleases(sources.Upstream{GitHubAPIURL: "://"}, "astral-sh", "uv", "", "", 0, nil)
+		if err == nil || !strings.Contains(err.Error(), "invalid GitHub API URL") {
+			t.Errorf("expected an URL error, got %v", err)
+		}
+	})
+}
+
+// githubRelease returns the JSON of a release of uv with its source asset.
+func githubRelease(tag string, draft, prerelease bool) string {
+	return fmt.Sprintf(`{"tag_name": %q, "draft": %t, "prerelease": %t, "assets": [
+		{"name": "source.tar.gz", "browser_download_url": "https://example.com/%[1]s/source.tar.gz", "digest": "sha256:%[4]s"},
+		{"name": "uv-x86_64-unknown-linux-gnu.tar.gz", "browser_download_url": "https://example.com/%[1]s/uv.tar.gz", "digest": "sha256:%[4]s"}
+	]}`, tag, draft, prerelease, strings.Repeat("a", 64))
+}
+
+// newPagedUpstream starts a server listing pages of releases of uv, linked
+// with the Link header as GitHub does, and records the pages requested.
+func newPagedUpstream(t *testing.T, pages [][]string) (sources.Upstream, *[]int) {
+	t.Helper()
+
+	var requested []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		if err != nil || page < 1 || page > len(pages) {
+			http.NotFound(w, r)
+			return
+		}
+		requested = append(requested, page)
+
+		if page < len(pages) {
+			next := *r.URL
+			query := next.Query()
+			query.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = query.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.String()))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(pages[page-1], ","))
+	}))
+	t.Cleanup(server.Close)
+
+	return sources.Upstream{
+		Client:       server.Client(),
+		GitHubAPIURL: server.URL + "/api/",
+	}, &requested
+}
+
+func releaseVersions(releases []sources.Release) string {
+	sortReleases(releases)
+
+	var versions []string
+	for _, release := range releases {
+		versions = append(versions, release.Version().String())
+	}
+	return strings.Join(versions, " ")
+}
+
+func TestGitHubReleasesPagination(t *testing.T) {
+	pages := [][]string{
+		{githubRelease("0.11.1", false, false), githubRelease("0.10.12", false, false)},
+		{githubRelease("0.11.0", false, false), githubRelease("0.10.11", false, false)},
+		{githubRelease("0.10.10", false, false)},
+	}
+
+	t.Run("stops once the constraints are satisfied", func(t *testing.T) {
+		upstream, requested := newPagedUpstream(t, pages)
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, []sources.Constraint{
+			{Constraint: "0.11.*", Patches: 2},
+			{Constraint: "0.10.*", Patches: 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		releases, err := source.Releases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := releaseVersions(releases); got != "0.10.11 0.10.12 0.11.0 0.11.1" {
+			t.Errorf("unexpected releases %s", got)
+		}
+		if fmt.Sprint(*requested) != "[1 2]" {
+			t.Errorf("expected pages 1 and 2 to be requested, got %v", *requested)
+		}
+	})
+
+	t.Run("lists every page when the constraints cannot be satisfied", func(t *testing.T) {
+		upstream, requested := newPagedUpstream(t, pages)
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, []sources.Constraint{
+			{Constraint: "0.10.*", Patches: 4},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		releases, err := source.Releases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := releaseVersions(releases); got != "0.10.10 0.10.11 0.10.12 0.11.0 0.11.1" {
+			t.Errorf("unexpected releases %s", got)
+		}
+		if fmt.Sprint(*requested) != "[1 2 3]" {
+			t.Errorf("expected every page to be requested, got %v", *requested)
+		}
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		upstream, requested := newPagedUpstream(t, pages)
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 3, []sources.Constraint{
+			{Constraint: "*", Patches: 10},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		releases, err := source.Releases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := releaseVersions(releases); got != "0.10.12 0.11.0 0.11.1" {
+			t.Errorf("unexpected releases %s", got)
+		}
+		if fmt.Sprint(*requested) != "[1 2]" {
+			t.Errorf("expected pages 1 and 2 to be requested, got %v", *requested)
+		}
+	})
+
+	t.Run("skips drafts, prereleases and tags that are not versions", func(t *testing.T) {
+		upstream, _ := newPagedUpstream(t, [][]string{{
+			githubRelease("0.12.0", true, false),
+			githubRelease("0.12.0rc1", false, true),
+			githubRelease("0.12.0-beta.1", false, false),
+			githubRelease("nightly", false, false),
+			githubRelease("0.11.1", false, false),
+		}})
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		releases, err := source.Releases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := releaseVersions(releases); got != "0.11.1" {
+			t.Errorf("unexpected releases %s", got)
+		}
+	})
+
+	t.Run("invalid constraint", func(t *testing.T) {
+		_, err := sources.NewGitHubReleases(sources.Upstream{}, "astral-sh", "uv", "", "", 0, []sources.Constraint{{Constraint: "not a constraint"}})
+		if err == nil || !strings.Contains(err.Error(), "invalid constraint") {
+			t.Errorf("expected a constraint error, got %v", err)
+		}
+	})
+}
+
+func TestGitHubReleasesAuthentication(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		fmt.Fprintf(w, "[%s]", githubRelease("0.11.1", false, false))
+	}))
+	defer server.Close()
+
+	upstream := sources.Upstream{Client: server.Client(), GitHubAPIURL: server.URL, GitHubToken: "some-token"}
+	source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := source.Releases(); err != nil {
+		t.Fatal(err)
+	}
+
+	if authorization != "Bearer some-token" {
+		t.Errorf("expected the token to be sent, got %q", authorization)
+	}
+}
+
+func TestGitHubReleasesRateLimit(t *testing.T) {
+	// rateLimited starts a server answering the first failures requests with
+	// respond, and returns the Upstream reaching it with the recorded waits.
+	rateLimited := func(t *testing.T, failures int, respond func(w http.ResponseWriter)) (sources.Upstream, *[]time.Duration) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests <= failures {
+				respond(w)
+				return
+			}
+			fmt.Fprintf(w, "[%s]", githubRelease("0.11.1", false, false))
+		}))
+		t.Cleanup(server.Close)
+
+		var waits []time.Duration
+		return sources.Upstream{
+			Client:       server.Client(),
+			GitHubAPIURL: server.URL,
+			Sleep:        func(d time.Duration) { waits = append(waits, d) },
+		}, &waits
+	}
+
+	primary := func(w http.ResponseWriter) {
+		// The reset is already reached, the client would refuse to retry
+		// before it otherwise
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(-time.Second).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"message": "API rate limit exceeded"}`)
+	}
+
+	t.Run("retries once the primary rate limit resets", func(t *testing.T) {
+		upstream, waits := rateLimited(t, 2, primary)
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		releases, err := source.Releases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := releaseVersions(releases); got != "0.11.1" {
+			t.Errorf("unexpected releases %s", got)
+		}
+		if fmt.Sprint(*waits) != "[1s 2s]" {
+			t.Errorf("expected increasing waits, got %v", *waits)
+		}
+	})
+
+	t.Run("retries after too many requests", func(t *testing.T) {
+		upstream, waits := rateLimited(t, 1, func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := source.Releases(); err != nil {
+			t.Fatal(err)
+		}
+
+		if fmt.Sprint(*waits) != "[7s]" {
+			t.Errorf("expected to wait as requested, got %v", *waits)
+		}
+	})
+
+	t.Run("gives up after the retries", func(t *testing.T) {
+		upstream, waits := rateLimited(t, sources.GitHubRetries+1, primary)
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = source.Releases()
+		if err == nil || !strings.Contains(err.Error(), "set GITHUB_TOKEN") {
+			t.Errorf("expected a rate limit error, got %v", err)
+		}
+		if len(*waits) != sources.GitHubRetries {
+			t.Errorf("expected %d waits, got %v", sources.GitHubRetries, *waits)
+		}
+	})
+
+	t.Run("does not wait for a distant reset", func(t *testing.T) {
+		upstream, waits := rateLimited(t, 1, func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "secondary rate limit", "documentation_url": "https://docs.github.com/rest/overview/rate-limits-for-the-rest-api#about-secondary-rate-limits"}`)
+		})
+
+		source, err := sources.NewGitHubReleases(upstream, "astral-sh", "uv", "uv-{arch}-unknown-linux-gnu.tar.gz", "", 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = source.Releases()
+		if err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
+			t.Errorf("expected a rate limit error, got %v", err)
+		}
+		if len(*waits) != 0 {
+			t.Errorf("expected no wait, got %v", *waits)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/sources"
+)
+
+// newUpstream starts a server replaying routes, a map of URL paths to the
+// name of a testdata file or, when there is no such file, the content itself,
+// and returns the Upstream reaching it.
+func newUpstream(t *testing.T, routes map[string]string) (sources.Upstream, *httptest.Server) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	for path, file := range routes {
+		content, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			content = []byte(file)
+		}
+
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(content)
+		})
+	}
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return sources.Upstream{
+		Client:       server.Client(),
+		PyPIURL:      server.URL + "/pypi",
+		GitHubAPIURL: server.URL + "/api/",
+	}, server
+}
+
+// sortReleases orders releases by version then architecture.
+func sortReleases(releases []sources.Release) {
+	sort.Slice(releases, func(i, j int) bool {
+		if !releases[i].Version().Equal(releases[j].Version()) {
+			return releases[i].Version().LessThan(releases[j].Version())
+		}
+		return releases[i].Arch < releases[j].Arch
+	})
+}
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/pep440"
+)
+
+// PyPIProductMetadataRaw is the part of the PyPI JSON API response used to
+// list the releases of a project.
+type PyPIProductMetadataRaw struct {
+	Releases map[string][]struct {
+		PackageType    string            `json:"packagetype"`
+		URL            string            `json:"url"`
+		UploadTime     string            `json:"upload_time_iso_8601"`
+		Digests        map[string]string `json:"digests"`
+		RequiresPython string            `json:"requires_python"`
+		Yanked         bool              `json:"yanked"`
+	} `json:"releases"`
+}
+
+// PyPI lists the source distributions of a PyPI project.
+type PyPI struct {
+	client      *http.Client
+	baseURL     string
+	project     string
+	prereleases bool
+}
+
+// NewPyPI returns the source listing the releases of project on the PyPI
+// of upstream. Prereleases are only listed when prereleases is set.
+func NewPyPI(upstream Upstream, project string, prereleases bool) PyPI {
+	return PyPI{
+		client:      upstream.Client,
+		baseURL:     strings.TrimSuffix(upstream.PyPIURL, "/"),
+		project:     project,
+		prereleases: prereleases,
+	}
+}
+
+// Releases returns the releases of the project having a source distribution
+// that was not yanked. Versions are parsed following PEP 440 and converted
+// to semver, see pep440.Version.Semver. Of the versions having the same
+// semver equivalent but for the build metadata, e.g. a post release, only
+// the most recent one is kept.
+func (source PyPI) Releases() ([]Release, error) {
+	url := fmt.Sprintf("%s/%s/json", source.baseURL, source.project)
+	response, err := source.client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve new versions from upstream: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not retrieve new versions from upstream: %s returned %s", url, response.Status)
+	}
+
+	var pypiMetadata PyPIProductMetadataRaw
+	err = json.NewDecoder(response.Body).Decode(&pypiMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the releases of %s: %w", source.project, err)
+	}
+
+	type candidate struct {
+		version pep440.Version
+		release Release
+	}
+	candidates := map[string]candidate{}
+
+	for version, releasesForVersion := range pypiMetadata.Releases {
+		pep440Version, err := pep440.Parse(version)
+		if err != nil {
+			fmt.Printf("skip %s %s: %s\n", source.project, version, err)
+			continue
+		}
+
+		if pep440Version.IsPrerelease() && !source.prereleases {
+			continue
+		}
+
+		newVersion, err := pep440Version.Semver()
+		if err != nil {
+			fmt.Printf("skip %s %s: %s\n", source.project, version, err)
+			continue
+		}
+
+		for _, release := range releasesForVersion {
+			if release.PackageType != "sdist" || release.Yanked {
+				continue
+			}
+
+			uploadTime, err := time.Parse(time.RFC3339, release.UploadTime)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse upload time '%s' as date for version %s: %w", release.UploadTime, version, err)
+			}
+
+			key := fmt.Sprintf("%d.%d.%d-%s", newVersion.Major(), newVersion.Minor(), newVersion.Patch(), newVersion.Prerelease())
+			if known, ok := candidates[key]; ok && known.version.Compare(pep440Version) > 0 {
+				continue
+			}
+
+			candidates[key] = candidate{
+				version: pep440Version,
+				release: Release{
+					SemverVersion:   newVersion,
+					UpstreamVersion: upstreamVersion(pep440Version, newVersion),
+					SourceSHA256:    release.Digests["sha256"],
+					SourceURL:       release.URL,
+					UploadTime:      uploadTime,
+					RequiresPython:  release.RequiresPython,
+				},
+			}
+		}
+	}
+
+	var releases []Release
+	for _, candidate := range candidates {
+		releases = append(releases, candidate.release)
+	}
+
+	return releases, nil
+}
+
+// upstreamVersion returns the PEP 440 version of the releases whose semver
+// equivalent has a prerelease or build metadata, e.g. 2024.0.1.post1 for
+// 2024.0.1+post1, as pip needs it to pin them. Its release has at least
+// three parts like the semver one, which PEP 440 considers the same.
+func upstreamVersion(version pep440.Version, semverVersion *semver.Version) string {
+	if semverVersion.Prerelease() == "" && semverVersion.Metadata() == "" {
+		return ""
+	}
+
+	padded := version
+	padded.Release = slices.Clone(version.Release)
+	for len(padded.Release) < 3 {
+		padded.Release = append(padded.Release, 0)
+	}
+	return padded.String()
+}
@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sources_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/sources"
+)
+
+func TestPyPIReleases(t *testing.T) {
+	upstream, _ := newUpstream(t, map[string]string{
+		"/pypi/poetry/json": "pypi_poetry.json",
+	})
+
+	releases, err := sources.NewPyPI(upstream, "poetry", false).Releases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sortReleases(releases)
+
+	// The wheel of 2.3.2 and the prerelease 2.0.0b1 are left out
+	if len(releases) != 2 {
+		t.Fatalf("expected 2 releases, got %d: %v", len(releases), releases)
+	}
+
+	release := releases[1]
+	if release.Version().String() != "2.3.2" {
+		t.Errorf("expected version 2.3.2, got %s", release.Version())
+	}
+	if release.SourceURL != "https://files.pythonhosted.org/packages/poetry-2.3.2.tar.gz" {
+		t.Errorf("unexpected source URL %s", release.SourceURL)
+	}
+	if release.SourceSHA256 != "7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b" {
+		t.Errorf("unexpected source sha256 %s", release.SourceSHA256)
+	}
+	if release.RequiresPython != "<4.0,>=3.10" {
+		t.Errorf("unexpected requires-python %s", release.RequiresPython)
+	}
+	if !release.UploadTime.Equal(time.Date(2026, 2, 1, 12, 0, 1, 0, time.UTC)) {
+		t.Errorf("unexpected upload time %s", release.UploadTime)
+	}
+	if release.BinaryURL != "" {
+		t.Errorf("expected no binary, got %s", release.BinaryURL)
+	}
+
+	if releases[0].Version().String() != "1.8.5" {
+		t.Errorf("expected version 1.8.5, got %s", releases[0].Version())
+	}
+}
+
+func TestPyPIReleasesVersions(t *testing.T) {
+	sdist := func(version string, yanked bool) string {
+		return fmt.Sprintf(`%q: [{"packagetype": "sdist", "url": "https://files.pythonhosted.org/packages/pipenv-%[1]s.tar.gz", "upload_time_iso_8601": "2026-01-01T00:00:00Z", "yanked": %t}]`, version, yanked)
+	}
+
+	content := fmt.Sprintf(`{"releases": {%s}}`, strings.Join([]string{
+		sdist("2024.0.1", false),
+		sdist("2024.0.1.post1", false),
+		sdist("2024.0.2", true),
+		sdist("2024.1.0rc1", false),
+		sdist("2024.1.0.dev3", false),
+		sdist("2023.10.3.1", false),
+		sdist("1!2.0", false),
+		sdist("not-a-version", false),
+	}, ","))
+
+	versions := func(releases []sources.Release) string {
+		var versions []string
+		for _, release := range releases {
+			versions = append(versions, release.Version().Original())
+		}
+		sort.Strings(versions)
+		return strings.Join(versions, " ")
+	}
+
+	t.Run("without prereleases", func(t *testing.T) {
+		upstream, _ := newUpstream(t, map[string]string{"/pypi/pipenv/json": content})
+
+		releases, err := sources.NewPyPI(upstream, "pipenv", false).Releases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The post release supersedes 2024.0.1, the yanked 2024.0.2 and the
+		// epoch without semver equivalent are left out
+		if got := versions(releases); got != "2023.10.3+1 2024.0.1+post1" {
+			t.Errorf("unexpected versions %s", got)
+		}
+
+		for _, release := range releases {
+			if release.Version().Original() == "2024.0.1+post1" && release.SourceURL != "https://files.pythonhosted.org/packages/pipenv-2024.0.1.post1.tar.gz" {
+				t.Errorf("expected the sources of the post release, got %s", release.SourceURL)
+			}
+		}
+	})
+
+	t.Run("with prereleases", func(t *testing.T) {
+		upstream, _ := newUpstream(t, map[string]string{"/pypi/pipenv/json": content})
+
+		releases, err := sources.NewPyPI(upstream, "pipenv", true).Releases()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := versions(releases); got != "2023.10.3+1 2024.0.1+post1 2024.1.0-0.dev.3 2024.1.0-rc.1" {
+			t.Errorf("unexpected versions %s", got)
+		}
+	})
+}
+
+func TestPyPIReleasesFailures(t *testing.T) {
+	t.Run("unknown project", func(t *testing.T) {
+		upstream, _ := newUpstream(t, map[string]string{})
+
+		_, err := sources.NewPyPI(upstream, "poetry", false).Releases()
+		if err == nil || !strings.Contains(err.Error(), "404 Not Found") {
+			t.Errorf("expected a not found error, got %v", err)
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		upstream, _ := newUpstream(t, map[string]string{
+			"/pypi/poetry/json": "{",
+		})
+
+		_, err := sources.NewPyPI(upstream, "poetry", false).Releases()
+		if err == nil || !strings.Contains(err.Error(), "could not parse the releases of poetry") {
+			t.Errorf("expected a parse error, got %v", err)
+		}
+	})
+
+	t.Run("invalid upload time", func(t *testing.T) {
+		upstream, _ := newUpstream(t, map[string]string{
+			"/pypi/poetry/json": `{"releases": {"1.0.0": [{"packagetype": "sdist", "upload_time_iso_8601": "yesterday"}]}}`,
+		})
+
+		_, err := sources.NewPyPI(upstream, "poetry", false).Releases()
+		if err == nil || !strings.Contains(err.Error(), "could not parse upload time 'yesterday'") {
+			t.Errorf("expected an upload time error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sources retrieves the releases of the dependencies of the buildpack
+// from their upstream, as described by the configuration of each dependency.
+package sources
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+const (
+	// PyPIType is the source type of the packages published on PyPI.
+	PyPIType = "pypi"
+
+	// GitHubType is the source type of the binaries attached to GitHub
+	// releases.
+	GitHubType = "github"
+
+	// AnacondaType is the source type of the installers listed in an
+	// Anaconda HTML index.
+	AnacondaType = "anaconda"
+)
+
+const (
+	// DefaultPyPIURL is the base URL of the PyPI JSON API.
+	DefaultPyPIURL = "https://pypi.org/pypi"
+
+	// DefaultGitHubAPIURL is the base URL of the GitHub REST API.
+	DefaultGitHubAPIURL = "https://api.github.com/"
+)
+
+// EnvGitHubToken is the environment variable holding the token used to
+// authenticate to the GitHub API, which raises its rate limit.
+const EnvGitHubToken = "GITHUB_TOKEN"
+
+// Upstream is how the sources reach their upstream. Tests point it to local
+// servers.
+type Upstream struct {
+	Client       *http.Client
+	PyPIURL      string
+	GitHubAPIURL string
+	GitHubToken  string
+
+	// Sleep waits for rate limits to reset, time.Sleep when nil.
+	Sleep func(time.Duration)
+}
+
+// DefaultUpstream returns the Upstream reaching the public services,
+// authenticated to GitHub with $GITHUB_TOKEN when set.
+func DefaultUpstream() Upstream {
+	return Upstream{
+		Client:       http.DefaultClient,
+		PyPIURL:      DefaultPyPIURL,
+		GitHubAPIURL: DefaultGitHubAPIURL,
+		GitHubToken:  os.Getenv(EnvGitHubToken),
+	}
+}
+
+// ArchMap maps the architecture names used upstream to the ones of
+// buildpack.toml.
+var ArchMap = map[string]string{
+	"x86_64":  "amd64",
+	"aarch64": "arm64",
+}
+
+// Release is a version of a dependency as published upstream. Releases of
+// binaries have one Release per architecture.
+type Release struct {
+	SemverVersion *semver.Version
+
+	// UpstreamVersion is the version as published upstream when it differs
+	// from SemverVersion, e.g. the PEP 440 version 2024.0.1.post1 of
+	// 2024.0.1+post1.
+	UpstreamVersion string
+
+	Arch           string
+	SourceURL      string
+	SourceSHA256   string
+	BinaryURL      string
+	BinarySHA256   string
+	UploadTime     time.Time
+	RequiresPython string
+}
+
+// Version returns the version of the release.
+func (release Release) Version() *semver.Version {
+	return release.SemverVersion
+}
+
+// Upstream returns the version of the release as published upstream.
+func (release Release) Upstream() string {
+	if release.UpstreamVersion != "" {
+		return release.UpstreamVersion
+	}
+	return release.SemverVersion.String()
+}
+
+// UpstreamSource lists the releases of a dependency.
+type UpstreamSource interface {
+	Releases() ([]Release, error)
+}
+
+// NewSource returns the UpstreamSource described by source, reached through
+// upstream. Sources listing releases page by page stop once constraints are
+// satisfied.
+func NewSource(source Source, upstream Upstream, constraints []Constraint) (UpstreamSource, error) {
+	switch source.Type {
+	case PyPIType:
+		return NewPyPI(upstream, source.Project, source.Prereleases), nil
+	case GitHubType:
+		org, repository, ok := strings.Cut(source.Project, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid github project %q, expected org/repository", source.Project)
+		}
+		return NewGitHubReleases(upstream, org, repository, source.Asset, source.SourceAsset, source.Limit, constraints)
+	case AnacondaType:
+		return NewAnacondaIndex(upstream, source.URL, source.Pattern, source.SourceURL, source.Limit)
+	}
+
+	return nil, fmt.Errorf("unknown source type %q, expected %q, %q or %q", source.Type, PyPIType, GitHubType, AnacondaType)
+}
+
+// Expand replaces the {version} and {arch} placeholders of template.
+func Expand(template, version, arch string) string {
+	return strings.NewReplacer("{version}", version, "{arch}", arch).Replace(template)
+}
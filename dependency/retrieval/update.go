@@ -0,0 +1,482 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/retrieval/wheelhouse"
+)
+
+const dependencyHeader = "[[metadata.dependencies]]"
+
+var (
+	headerPattern         = regexp.MustCompile(`^\s*\[`)
+	defaultVersionPattern = regexp.MustCompile(`^(\s*)("?)([A-Za-z0-9_-]+)("?)(\s*=\s*)"([^"]*)"(.*)$`)
+)
+
+// UpdateDependency is a dependency of the metadata written by the retrieval.
+type UpdateDependency struct {
+	cargo.ConfigMetadataDependency
+	RequiresPython string `json:"requires-python,omitempty" toml:"requires-python"`
+	Target         string `json:"target,omitempty" toml:"-"`
+}
+
+// Change is a line of the changelog of an update.
+type Change struct {
+	Kind    string
+	ID      string
+	Version string
+	Details string
+}
+
+// section is a table of buildpack.toml, from its header to the next one.
+type section struct {
+	lines      []string
+	dependency *UpdateDependency
+}
+
+// buildpackTomlMetadata is the part of buildpack.toml driving the update.
+type buildpackTomlMetadata struct {
+	Metadata struct {
+		DefaultVersions       map[string]string                          `toml:"default-versions"`
+		DependencyConstraints []cargo.ConfigMetadataDependencyConstraint `toml:"dependency-constraints"`
+	} `toml:"metadata"`
+}
+
+// updateBuildpackToml merges dependencies into content, the content of a
+// buildpack.toml, and removes the versions beyond the patches of its
+// dependency constraints. The lines not concerned are kept as they are.
+func updateBuildpackToml(content []byte, dependencies []UpdateDependency) ([]byte, []Change, error) {
+	var metadata buildpackTomlMetadata
+	if _, err := toml.Decode(string(content), &metadata); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse buildpack.toml: %w", err)
+	}
+
+	sections, err := splitSections(string(content))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changes []Change
+
+	for _, dependency := range dependencies {
+		if dependency.URI == "" {
+			changes = append(changes, Change{Kind: "skipped", ID: dependency.ID, Version: dependency.Version, Details: fmt.Sprintf("%s, not compiled yet", dependency.Target)})
+			continue
+		}
+
+		if slices.ContainsFunc(sections, func(s section) bool { return s.dependency != nil && sameDependency(*s.dependency, dependency) }) {
+			continue
+		}
+
+		version, err := semver.NewVersion(dependency.Version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid version %q of %s: %w", dependency.Version, dependency.ID, err)
+		}
+
+		newSection, err := renderDependency(dependency)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		sections = slices.Insert(sections, insertionIndex(sections, dependency.ID, version), newSection)
+		changes = append(changes, Change{Kind: "added", ID: dependency.ID, Version: dependency.Version, Details: dependency.Arch})
+	}
+
+	removed, err := prunedVersions(sections, metadata.Metadata.DependencyConstraints)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var kept []section
+	for _, s := range sections {
+		if s.dependency != nil && removed[s.dependency.ID][s.dependency.Version] {
+			changes = append(changes, Change{Kind: "removed", ID: s.dependency.ID, Version: s.dependency.Version, Details: s.dependency.Arch})
+			continue
+		}
+		kept = append(kept, s)
+	}
+	sections = kept
+
+	defaultChanges, err := updateDefaultVersions(sections, metadata.Metadata.DefaultVersions)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes = append(changes, defaultChanges...)
+
+	var buffer bytes.Buffer
+	for _, s := range sections {
+		for _, line := range s.lines {
+			buffer.WriteString(line)
+		}
+	}
+
+	return buffer.Bytes(), mergeChanges(changes), nil
+}
+
+// splitSections splits content before each table header. The sections of
+// the dependencies carry the dependency they describe.
+func splitSections(content string) ([]section, error) {
+	sections := []section{{}}
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if line == "" {
+			continue
+		}
+		if headerPattern.MatchString(line) {
+			sections = append(sections, section{})
+		}
+		current := &sections[len(sections)-1]
+		current.lines = append(current.lines, line)
+	}
+
+	for i := range sections {
+		if len(sections[i].lines) == 0 || strings.TrimSpace(sections[i].lines[0]) != dependencyHeader {
+			continue
+		}
+
+		var dependency UpdateDependency
+		body := strings.Join(sections[i].lines[1:], "")
+		if _, err := toml.Decode(body, &dependency); err != nil {
+			return nil, fmt.Errorf("failed to parse the dependency %q: %w", body, err)
+		}
+		sections[i].dependency = &dependency
+	}
+
+	return sections, nil
+}
+
+// renderDependency renders dependency in the layout of buildpack.toml: an
+// indented table with its keys sorted.
+func renderDependency(dependency UpdateDependency) (section, error) {
+	values := map[string]any{}
+	add := func(key string, value any, present bool) {
+		if present {
+			values[key] = value
+		}
+	}
+
+	add("arch", dependency.Arch, dependency.Arch != "")
+	add("checksum", dependency.Checksum, dependency.Checksum != "")
+	add("cpe", dependency.CPE, dependency.CPE != "")
+	add("deprecation_date", dependency.DeprecationDate, dependency.DeprecationDate != nil)
+	add("id", dependency.ID, true)
+	add("licenses", dependency.Licenses, len(dependency.Licenses) > 0)
+	add("name", dependency.Name, dependency.Name != "")
+	add("os", dependency.OS, dependency.OS != "")
+	add("purl", dependency.PURL, dependency.PURL != "")
+	add("requires-python", dependency.RequiresPython, dependency.RequiresPython != "")
+	add("source", dependency.Source, dependency.Source != "")
+	add("source-checksum", dependency.SourceChecksum, dependency.SourceChecksum != "")
+	add("stacks", dependency.Stacks, len(dependency.Stacks) > 0)
+	add("strip-components", dependency.StripComponents, dependency.StripComponents != 0)
+	add("uri", dependency.URI, true)
+	add("version", dependency.Version, true)
+
+	var buffer bytes.Buffer
+	if err := toml.NewEncoder(&buffer).Encode(values); err != nil {
+		return section{}, fmt.Errorf("failed to render %s %s: %w", dependency.ID, dependency.Version, err)
+	}
+
+	lines := []string{"  " + dependencyHeader + "\n"}
+	for _, line := range strings.SplitAfter(buffer.String(), "\n") {
+		if line != "" {
+			lines = append(lines, "    "+line)
+		}
+	}
+	lines = append(lines, "\n")
+
+	return section{lines: lines, dependency: &dependency}, nil
+}
+
+// insertionIndex returns where a dependency of id at version goes: before
+// the first one of id with a greater version, after the last one of id, or
+// after the last dependency for a new id.
+func insertionIndex(sections []section, id string, version *semver.Version) int {
+	lastOfID, lastDependency := -1, -1
+	for i, s := range sections {
+		if s.dependency == nil {
+			continue
+		}
+		lastDependency = i
+		if s.dependency.ID != id {
+			continue
+		}
+		lastOfID = i
+
+		existing, err := semver.NewVersion(s.dependency.Version)
+		if err == nil && existing.GreaterThan(version) {
+			return i
+		}
+	}
+
+	switch {
+	case lastOfID >= 0:
+		return lastOfID + 1
+	case lastDependency >= 0:
+		return lastDependency + 1
+	}
+	return len(sections)
+}
+
+// prunedVersions returns, per id, the versions matching a dependency
+// constraint without being among the patches newest versions of any of the
+// constraints they match, along with the wheelhouses of these versions.
+// Versions matching no constraint are kept.
+func prunedVersions(sections []section, constraints []cargo.ConfigMetadataDependencyConstraint) (map[string]map[string]bool, error) {
+	versions := map[string][]*semver.Version{}
+	seen := map[string]bool{}
+	for _, s := range sections {
+		if s.dependency == nil || seen[s.dependency.ID+"@"+s.dependency.Version] {
+			continue
+		}
+		seen[s.dependency.ID+"@"+s.dependency.Version] = true
+
+		version, err := semver.NewVersion(s.dependency.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q of %s: %w", s.dependency.Version, s.dependency.ID, err)
+		}
+		versions[s.dependency.ID] = append(versions[s.dependency.ID], version)
+	}
+
+	matched := map[string]map[string]bool{}
+	kept := map[string]map[string]bool{}
+	for _, constraint := range constraints {
+		c, err := semver.NewConstraint(constraint.Constraint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid constraint %q of %s: %w", constraint.Constraint, constraint.ID, err)
+		}
+
+		var matching []*semver.Version
+		for _, version := range versions[constraint.ID] {
+			if c.Check(version) {
+				matching = append(matching, version)
+			}
+		}
+		sort.Sort(sort.Reverse(semver.Collection(matching)))
+
+		if matched[constraint.ID] == nil {
+			matched[constraint.ID], kept[constraint.ID] = map[string]bool{}, map[string]bool{}
+		}
+		for i, version := range matching {
+			matched[constraint.ID][version.Original()] = true
+			if i < constraint.Patches {
+				kept[constraint.ID][version.Original()] = true
+			}
+		}
+	}
+
+	removed := map[string]map[string]bool{}
+	remove := func(id, version string) {
+		if removed[id] == nil {
+			removed[id] = map[string]bool{}
+		}
+		removed[id][version] = true
+	}
+	for id, versions := range matched {
+		for version := range versions {
+			if !kept[id][version] {
+				remove(id, version)
+			}
+		}
+	}
+
+	// The wheelhouses go along with the versions of their tool
+	for id, versions := range versions {
+		tool, _, ok := wheelhouse.Parse(id)
+		if !ok {
+			continue
+		}
+		for _, version := range versions {
+			if removed[tool][version.Original()] {
+				remove(id, version.Original())
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+// updateDefaultVersions points the default versions matching none of the
+// remaining versions of their dependency to the newest one, keeping their
+// form: an exact version, X.* or X.Y.*.
+func updateDefaultVersions(sections []section, defaultVersions map[string]string) ([]Change, error) {
+	newest := map[string]*semver.Version{}
+	versions := map[string][]*semver.Version{}
+	for _, s := range sections {
+		if s.dependency == nil {
+			continue
+		}
+		version, err := semver.NewVersion(s.dependency.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q of %s: %w", s.dependency.Version, s.dependency.ID, err)
+		}
+		versions[s.dependency.ID] = append(versions[s.dependency.ID], version)
+		if newest[s.dependency.ID] == nil || version.GreaterThan(newest[s.dependency.ID]) {
+			newest[s.dependency.ID] = version
+		}
+	}
+
+	replacements := map[string]string{}
+	var changes []Change
+	for id, defaultVersion := range defaultVersions {
+		if newest[id] == nil {
+			continue
+		}
+
+		constraint, err := semver.NewConstraint(defaultVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default version %q of %s: %w", defaultVersion, id, err)
+		}
+		if slices.ContainsFunc(versions[id], constraint.Check) {
+			continue
+		}
+
+		var replacement string
+		switch parts := strings.Split(defaultVersion, "."); {
+		case len(parts) == 2 && parts[1] == "*":
+			replacement = fmt.Sprintf("%d.*", newest[id].Major())
+		case len(parts) == 3 && parts[2] == "*":
+			replacement = fmt.Sprintf("%d.%d.*", newest[id].Major(), newest[id].Minor())
+		default:
+			if _, err := semver.StrictNewVersion(defaultVersion); err != nil {
+				changes = append(changes, Change{Kind: "warning", ID: id, Details: fmt.Sprintf("default version %s matches no version", defaultVersion)})
+				continue
+			}
+			replacement = newest[id].Original()
+		}
+
+		replacements[id] = replacement
+		changes = append(changes, Change{Kind: "default", ID: id, Details: fmt.Sprintf("%s -> %s", defaultVersion, replacement)})
+	}
+
+	for i := range sections {
+		if len(sections[i].lines) == 0 || strings.TrimSpace(sections[i].lines[0]) != "[metadata.default-versions]" {
+			continue
+		}
+		for j, line := range sections[i].lines[1:] {
+			groups := defaultVersionPattern.FindStringSubmatch(strings.TrimSuffix(line, "\n"))
+			if groups == nil {
+				continue
+			}
+			if replacement, ok := replacements[groups[3]]; ok {
+				sections[i].lines[j+1] = fmt.Sprintf("%s%s%s%s%s%q%s\n", groups[1], groups[2], groups[3], groups[4], groups[5], replacement, groups[7])
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+func sameDependency(a, b UpdateDependency) bool {
+	return a.ID == b.ID && a.Version == b.Version && a.OS == b.OS && a.Arch == b.Arch
+}
+
+// mergeChanges merges the changes of the architectures of a version and
+// sorts them by id.
+func mergeChanges(changes []Change) []Change {
+	var merged []Change
+	for _, change := range changes {
+		index := slices.IndexFunc(merged, func(c Change) bool {
+			return c.Kind == change.Kind && c.ID == change.ID && c.Version == change.Version && c.Version != ""
+		})
+		if index < 0 {
+			merged = append(merged, change)
+			continue
+		}
+		if change.Details != "" {
+			merged[index].Details = strings.TrimPrefix(merged[index].Details+", "+change.Details, ", ")
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].ID < merged[j].ID
+	})
+
+	return merged
+}
+
+// writeChangelog writes changes, one line per change.
+func writeChangelog(w io.Writer, changes []Change) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "No changes to buildpack.toml")
+		return
+	}
+
+	fmt.Fprintln(w, "Changes to buildpack.toml:")
+	for _, change := range changes {
+		line := strings.TrimSpace(fmt.Sprintf("%s %s", change.ID, change.Version))
+		if change.Details != "" {
+			if change.Version != "" {
+				line += fmt.Sprintf(" (%s)", change.Details)
+			} else {
+				line += " " + change.Details
+			}
+		}
+		fmt.Fprintf(w, "  %-8s %s\n", change.Kind, line)
+	}
+}
+
+// runUpdate implements the update subcommand.
+func runUpdate(args []string) error {
+	flags := flag.NewFlagSet("update", flag.ContinueOnError)
+	buildpackTomlPath := flags.String("buildpack-toml-path", "", "full path to the buildpack.toml file to update")
+	metadataPath := flags.String("metadata", "", "path to the JSON metadata written by the retrieval")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *buildpackTomlPath == "" || *metadataPath == "" {
+		return errors.New("buildpack-toml-path and metadata are required")
+	}
+
+	content, err := os.ReadFile(*buildpackTomlPath)
+	if err != nil {
+		return err
+	}
+
+	metadataContent, err := os.ReadFile(*metadataPath)
+	if err != nil {
+		return err
+	}
+
+	var dependencies []UpdateDependency
+	if err := json.Unmarshal(metadataContent, &dependencies); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", *metadataPath, err)
+	}
+
+	updated, changes, err := updateBuildpackToml(content, dependencies)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(*buildpackTomlPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*buildpackTomlPath, updated, info.Mode()); err != nil {
+		return fmt.Errorf("cannot write to %s: %w", *buildpackTomlPath, err)
+	}
+
+	writeChangelog(os.Stdout, changes)
+
+	return nil
+}
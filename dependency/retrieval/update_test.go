@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+)
+
+const updateBuildpackTomlContent = `api = "0.8"
+
+[metadata]
+  # Kept as is
+  include-files = ["buildpack.toml"]
+
+  [metadata.default-versions]
+    pip = "26.*"
+    uv = "0.10.*"
+
+  [[metadata.dependencies]]
+    id = "pip"
+    requires-python = ">=3.9"
+    stacks = ["*"]
+    uri = "https://example.com/pip-26.0.0.tgz"
+    version = "26.0.0"
+
+  [[metadata.dependencies]]
+    arch = "amd64"
+    id = "uv"
+    uri = "https://example.com/uv-0.10.10-x86_64.tgz"
+    version = "0.10.10"
+
+  [[metadata.dependencies]]
+    arch = "arm64"
+    id = "uv"
+    uri = "https://example.com/uv-0.10.10-aarch64.tgz"
+    version = "0.10.10"
+
+  [[metadata.dependencies]]
+    arch = "amd64"
+    id = "uv"
+    uri = "https://example.com/uv-0.10.11-x86_64.tgz"
+    version = "0.10.11"
+
+  [[metadata.dependency-constraints]]
+    constraint = "*"
+    id = "pip"
+    patches = 2
+
+  [[metadata.dependency-constraints]]
+    constraint = "*"
+    id = "uv"
+    patches = 2
+
+[[stacks]]
+  id = "*"
+`
+
+func TestUpdateBuildpackToml(t *testing.T) {
+	deprecationDate := time.Date(2027, 3, 10, 0, 0, 0, 0, time.UTC)
+	dependency := func(id, version, arch string) UpdateDependency {
+		return UpdateDependency{ConfigMetadataDependency: cargo.ConfigMetadataDependency{
+			ID:      id,
+			Version: version,
+			Arch:    arch,
+			URI:     "https://example.com/" + id + "-" + version + "-" + arch + ".tgz",
+		}}
+	}
+
+	pip := dependency("pip", "26.0.1", "")
+	pip.DeprecationDate = &deprecationDate
+	pip.Licenses = []interface{}{"MIT"}
+	pip.Stacks = []string{"*"}
+	pip.RequiresPython = ">=3.9"
+
+	uncompiled := UpdateDependency{ConfigMetadataDependency: cargo.ConfigMetadataDependency{ID: "pip", Version: "26.1.0"}, Target: "noarch"}
+
+	updated, changes, err := updateBuildpackToml([]byte(updateBuildpackTomlContent), []UpdateDependency{
+		pip,
+		uncompiled,
+		dependency("uv", "0.11.0", "amd64"),
+		dependency("uv", "0.11.0", "arm64"),
+		dependency("uv", "0.11.1", "amd64"),
+		// Already listed
+		{ConfigMetadataDependency: cargo.ConfigMetadataDependency{ID: "uv", Version: "0.10.11", Arch: "amd64", URI: "https://example.com/other"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `api = "0.8"
+
+[metadata]
+  # Kept as is
+  include-files = ["buildpack.toml"]
+
+  [metadata.default-versions]
+    pip = "26.*"
+    uv = "0.11.*"
+
+  [[metadata.dependencies]]
+    id = "pip"
+    requires-python = ">=3.9"
+    stacks = ["*"]
+    uri = "https://example.com/pip-26.0.0.tgz"
+    version = "26.0.0"
+
+  [[metadata.dependencies]]
+    deprecation_date = 2027-03-10T00:00:00Z
+    id = "pip"
+    licenses = ["MIT"]
+    requires-python = ">=3.9"
+    stacks = ["*"]
+    uri = "https://example.com/pip-26.0.1-.tgz"
+    version = "26.0.1"
+
+  [[metadata.dependencies]]
+    arch = "amd64"
+    id = "uv"
+    uri = "https://example.com/uv-0.11.0-amd64.tgz"
+    version = "0.11.0"
+
+  [[metadata.dependencies]]
+    arch = "arm64"
+    id = "uv"
+    uri = "https://example.com/uv-0.11.0-arm64.tgz"
+    version = "0.11.0"
+
+  [[metadata.dependencies]]
+    arch = "amd64"
+    id = "uv"
+    uri = "https://example.com/uv-0.11.1-amd64.tgz"
+    version = "0.11.1"
+
+  [[metadata.dependency-constraints]]
+    constraint = "*"
+    id = "pip"
+    patches = 2
+
+  [[metadata.dependency-constraints]]
+    constraint = "*"
+    id = "uv"
+    patches = 2
+
+[[stacks]]
+  id = "*"
+`
+	if string(updated) != expected {
+		t.Errorf("unexpected buildpack.toml:\n%s", updated)
+	}
+
+	var changelog bytes.Buffer
+	writeChangelog(&changelog, changes)
+
+	expectedChangelog := `Changes to buildpack.toml:
+  added    pip 26.0.1
+  skipped  pip 26.1.0 (noarch, not compiled yet)
+  added    uv 0.11.0 (amd64, arm64)
+  added    uv 0.11.1 (amd64)
+  removed  uv 0.10.10 (amd64, arm64)
+  removed  uv 0.10.11 (amd64)
+  default  uv 0.10.* -> 0.11.*
+`
+	if changelog.String() != expectedChangelog {
+		t.Errorf("unexpected changelog:\n%s", changelog.String())
+	}
+}
+
+func TestUpdateBuildpackTomlWheelhouses(t *testing.T) {
+	content := `api = "0.8"
+
+[metadata]
+
+  [[metadata.dependencies]]
+    id = "poetry"
+    uri = "https://example.com/poetry-2.3.1.tar.gz"
+    version = "2.3.1"
+
+  [[metadata.dependencies]]
+    arch = "amd64"
+    id = "poetry-cp312"
+    uri = "https://example.com/poetry-2.3.1-cp312.tgz"
+    version = "2.3.1"
+
+  [[metadata.dependency-constraints]]
+    constraint = "*"
+    id = "poetry"
+    patches = 1
+`
+
+	updated, changes, err := updateBuildpackToml([]byte(content), []UpdateDependency{
+		{ConfigMetadataDependency: cargo.ConfigMetadataDependency{ID: "poetry", Version: "2.3.2", URI: "https://example.com/poetry-2.3.2.tar.gz"}},
+		{ConfigMetadataDependency: cargo.ConfigMetadataDependency{ID: "poetry-cp312", Version: "2.3.2", Arch: "amd64", URI: "https://example.com/poetry-2.3.2-cp312.tgz"}, Target: "wheelhouse-cp312"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(updated), "2.3.1") {
+		t.Errorf("expected the wheelhouse of the pruned version to be removed:\n%s", updated)
+	}
+
+	var changelog bytes.Buffer
+	writeChangelog(&changelog, changes)
+
+	expectedChangelog := `Changes to buildpack.toml:
+  added    poetry 2.3.2
+  removed  poetry 2.3.1
+  added    poetry-cp312 2.3.2 (amd64)
+  removed  poetry-cp312 2.3.1 (amd64)
+`
+	if changelog.String() != expectedChangelog {
+		t.Errorf("unexpected changelog:\n%s", changelog.String())
+	}
+}
+
+func TestUpdateBuildpackTomlUnchanged(t *testing.T) {
+	updated, changes, err := updateBuildpackToml([]byte(updateBuildpackTomlContent), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(updated) != updateBuildpackTomlContent {
+		t.Errorf("expected buildpack.toml to be kept as is, got:\n%s", updated)
+	}
+
+	var changelog bytes.Buffer
+	writeChangelog(&changelog, changes)
+	if strings.TrimSpace(changelog.String()) != "No changes to buildpack.toml" {
+		t.Errorf("unexpected changelog %q", changelog.String())
+	}
+}
+
+func TestUpdateBuildpackTomlFailures(t *testing.T) {
+	_, _, err := updateBuildpackToml([]byte("[metadata"), nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to parse buildpack.toml") {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+
+	_, _, err = updateBuildpackToml([]byte(updateBuildpackTomlContent), []UpdateDependency{
+		{ConfigMetadataDependency: cargo.ConfigMetadataDependency{ID: "uv", Version: "latest", URI: "https://example.com/uv"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `invalid version "latest" of uv`) {
+		t.Errorf("expected a version error, got %v", err)
+	}
+}
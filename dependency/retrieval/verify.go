@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Verifier downloads the artifacts of the retrieved dependencies to check
+// them before their metadata is written. Each URL is downloaded once.
+type Verifier struct {
+	client   *http.Client
+	verified map[string]error
+}
+
+// NewVerifier returns a Verifier downloading with client.
+func NewVerifier(client *http.Client) Verifier {
+	return Verifier{
+		client:   client,
+		verified: map[string]error{},
+	}
+}
+
+// Verify checks the artifacts of dependencies: the binary against its
+// checksum and, for archives, that it holds executable; the sources
+// against their checksum.
+func (verifier Verifier) Verify(dependencies []RetrievedDependency, executable string) error {
+	for _, dependency := range dependencies {
+		if dependency.URI != "" {
+			if err := verifier.verify(dependency.URI, dependency.Checksum, executable); err != nil {
+				return fmt.Errorf("failed to verify %s %s: %w", dependency.ID, dependency.Version, err)
+			}
+		}
+
+		if dependency.Source != "" && dependency.Source != dependency.URI {
+			if err := verifier.verify(dependency.Source, dependency.SourceChecksum, ""); err != nil {
+				return fmt.Errorf("failed to verify the sources of %s %s: %w", dependency.ID, dependency.Version, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (verifier Verifier) verify(url, checksum, executable string) error {
+	if err, ok := verifier.verified[url]; ok {
+		return err
+	}
+
+	fmt.Printf("Verifying %s\n", url)
+	err := verifier.download(url, checksum, executable)
+	verifier.verified[url] = err
+	return err
+}
+
+// download streams the artifact at url through SHA-256 while inspecting its
+// content.
+func (verifier Verifier) download(url, checksum, executable string) error {
+	expected, found := strings.CutPrefix(checksum, "sha256:")
+	if !found {
+		return fmt.Errorf("unsupported checksum %q of %s", checksum, url)
+	}
+
+	response, err := verifier.client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not download %s: %s", url, response.Status)
+	}
+
+	hash := sha256.New()
+	content := io.TeeReader(response.Body, hash)
+
+	var inspectErr error
+	switch {
+	case strings.HasSuffix(url, ".sh"):
+		inspectErr = checkScript(content)
+	case executable != "" && (strings.HasSuffix(url, ".tar.gz") || strings.HasSuffix(url, ".tgz")):
+		inspectErr = checkArchive(content, executable)
+	}
+
+	// The rest of the artifact is needed for its checksum
+	if _, err := io.Copy(io.Discard, content); err != nil {
+		return fmt.Errorf("could not download %s: %w", url, err)
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", url, expected, actual)
+	}
+
+	if inspectErr != nil {
+		return fmt.Errorf("unexpected content of %s: %w", url, inspectErr)
+	}
+
+	return nil
+}
+
+// checkScript checks that content starts with a shebang, as the installers
+// do.
+func checkScript(content io.Reader) error {
+	start, err := bufio.NewReader(content).Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	if string(start) != "#!" {
+		return errors.New("not an installer script")
+	}
+	return nil
+}
+
+// checkArchive checks that the tar.gz content holds an executable file named
+// executable.
+func checkArchive(content io.Reader, executable string) error {
+	gzipReader, err := gzip.NewReader(content)
+	if err != nil {
+		return err
+	}
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag == tar.TypeReg && path.Base(header.Name) == executable {
+			if header.FileInfo().Mode().Perm()&0o111 == 0 {
+				return fmt.Errorf("%s is not executable", header.Name)
+			}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no %s executable in the archive", executable)
+}
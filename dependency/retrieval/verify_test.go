@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joshuatcasey/libdependency/versionology"
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+)
+
+// tarGz returns a tar.gz archive holding files, a map of names to modes.
+func tarGz(t *testing.T, files map[string]int64) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, mode := range files {
+		content := []byte("content of " + name)
+		if err := tarWriter.WriteHeader(&tar.Header{Name: name, Mode: mode, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes()
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func TestVerifier(t *testing.T) {
+	archive := tarGz(t, map[string]int64{"uv-x86_64-unknown-linux-gnu/uv": 0o755})
+	notExecutable := tarGz(t, map[string]int64{"uv-x86_64-unknown-linux-gnu/uv": 0o644})
+	sources := []byte("sources")
+	installer := []byte("#!/bin/sh\necho installing\n")
+
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/uv.tar.gz":
+			_, _ = w.Write(archive)
+		case "/not-executable.tar.gz":
+			_, _ = w.Write(notExecutable)
+		case "/source.tar.gz":
+			_, _ = w.Write(sources)
+		case "/installer.sh":
+			_, _ = w.Write(installer)
+		case "/not-installer.sh":
+			_, _ = w.Write([]byte("<html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dependency := func(uri, sum, source, sourceSum string) RetrievedDependency {
+		return RetrievedDependency{Dependency: versionology.Dependency{ConfigMetadataDependency: cargo.ConfigMetadataDependency{
+			ID:             "uv",
+			Version:        "0.10.11",
+			URI:            server.URL + uri,
+			Checksum:       sum,
+			Source:         server.URL + source,
+			SourceChecksum: sourceSum,
+		}}}
+	}
+
+	t.Run("valid artifacts", func(t *testing.T) {
+		verifier := NewVerifier(server.Client())
+		err := verifier.Verify([]RetrievedDependency{
+			dependency("/uv.tar.gz", checksum(archive), "/source.tar.gz", checksum(sources)),
+			dependency("/installer.sh", checksum(installer), "/source.tar.gz", checksum(sources)),
+		}, "uv")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if requests["/source.tar.gz"] != 1 {
+			t.Errorf("expected the sources to be downloaded once, got %d", requests["/source.tar.gz"])
+		}
+	})
+
+	for _, tc := range []struct {
+		name       string
+		dependency RetrievedDependency
+		message    string
+	}{
+		{
+			"checksum mismatch",
+			dependency("/uv.tar.gz", checksum(sources), "/source.tar.gz", checksum(sources)),
+			"checksum mismatch for " + server.URL + "/uv.tar.gz",
+		},
+		{
+			"source checksum mismatch",
+			dependency("/uv.tar.gz", checksum(archive), "/source.tar.gz", checksum(archive)),
+			"failed to verify the sources of uv 0.10.11: checksum mismatch",
+		},
+		{
+			"unsupported checksum",
+			dependency("/uv.tar.gz", "md5:abc", "/source.tar.gz", checksum(sources)),
+			`unsupported checksum "md5:abc"`,
+		},
+		{
+			"missing executable",
+			dependency("/source.tar.gz", checksum(sources), "/source.tar.gz", checksum(sources)),
+			"unexpected content of " + server.URL + "/source.tar.gz",
+		},
+		{
+			"not executable",
+			dependency("/not-executable.tar.gz", checksum(notExecutable), "/source.tar.gz", checksum(sources)),
+			"uv-x86_64-unknown-linux-gnu/uv is not executable",
+		},
+		{
+			"not an installer",
+			dependency("/not-installer.sh", checksum([]byte("<html>")), "/source.tar.gz", checksum(sources)),
+			"not an installer script",
+		},
+		{
+			"not found",
+			dependency("/missing.tar.gz", checksum(archive), "/source.tar.gz", checksum(sources)),
+			"404 Not Found",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewVerifier(server.Client()).Verify([]RetrievedDependency{tc.dependency}, "uv")
+			if err == nil || !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("expected %q, got %v", tc.message, err)
+			}
+		})
+	}
+
+	t.Run("missing executable in a tar.gz", func(t *testing.T) {
+		other := tarGz(t, map[string]int64{"pixi": 0o755})
+		err := checkArchive(bytes.NewReader(other), "uv")
+		if err == nil || !strings.Contains(err.Error(), "no uv executable in the archive") {
+			t.Errorf("expected a missing executable error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,438 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pep440"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
+)
+
+// Result is the outcome of a check of an artifact, Err is nil when it
+// passed.
+type Result struct {
+	Check string
+	Err   error
+}
+
+func (result Result) String() string {
+	if result.Err != nil {
+		return fmt.Sprintf("FAIL %s: %s", result.Check, result.Err)
+	}
+	return fmt.Sprintf("ok   %s", result.Check)
+}
+
+// Artifact is a dependency artifact to check.
+type Artifact struct {
+	// Path is the path of the artifact.
+	Path string
+
+	// Dir is where the artifact is unpacked, empty for the artifacts that
+	// are not archives.
+	Dir string
+
+	// Arch is the architecture of the artifact, e.g. amd64, empty when it
+	// is not known.
+	Arch string
+}
+
+// Checker checks an artifact against the expected version of its
+// dependency.
+type Checker struct {
+	// Archive is whether the artifact is a tarball to unpack.
+	Archive bool
+
+	Check func(artifact Artifact, version string) []Result
+}
+
+// Checkers are the checkers of the dependencies of the buildpack by id.
+var Checkers = map[string]Checker{
+	"miniconda3": {Check: checkInstaller},
+	"pip":        {Archive: true, Check: checkPythonPackage("pip")},
+	"pipenv":     {Archive: true, Check: checkPythonPackage("pipenv")},
+	"poetry":     {Archive: true, Check: checkPythonPackage("poetry")},
+	"pixi":       {Archive: true, Check: checkBinary("pixi", "pixi")},
+	"uv":         {Archive: true, Check: checkBinary("uv", "uv-{arch}-unknown-linux-gnu/uv")},
+}
+
+// archNames maps the architectures of buildpack.toml to the ones used in
+// the artifacts.
+var archNames = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+}
+
+// Check checks the artifact at path of the dependency id, unpacked in a
+// temporary directory when it is an archive. A wheelhouse is checked by the
+// checker of its tool.
+func Check(id, path, version, arch string) ([]Result, error) {
+	if tool, _, ok := wheelhouse.Parse(id); ok {
+		id = tool
+	}
+
+	checker, ok := Checkers[id]
+	if !ok {
+		return nil, fmt.Errorf("no checks for dependency %q", id)
+	}
+
+	artifact := Artifact{Path: path, Arch: arch}
+
+	if checker.Archive {
+		dir, err := os.MkdirTemp("", "artifact")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+
+		if err := extract(path, dir); err != nil {
+			return []Result{{Check: "unpack", Err: err}}, nil
+		}
+		artifact.Dir = dir
+	}
+
+	return checker.Check(artifact, version), nil
+}
+
+// extract unpacks the tarball at path, compressed with gzip or not, into
+// dir.
+func extract(path, dir string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var content io.Reader = reader
+	if magic, _ := reader.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return err
+		}
+		content = gzipReader
+	}
+
+	tarReader := tar.NewReader(content)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid tarball: %w", err)
+		}
+
+		target := filepath.Join(dir, header.Name)
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) && target != filepath.Clean(dir) {
+			return fmt.Errorf("invalid tarball: %s is outside of the archive", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, header.FileInfo().Mode().Perm())
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(out, tarReader)
+			if closeErr := out.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkBinary checks archives holding the executable of a tool at layout,
+// with an {arch} placeholder, and that it reports the expected version when
+// it can run on this machine.
+func checkBinary(name, layout string) func(Artifact, string) []Result {
+	return func(artifact Artifact, version string) []Result {
+		pattern := strings.ReplaceAll(layout, "{arch}", "*")
+		if arch, ok := archNames[artifact.Arch]; ok {
+			pattern = strings.ReplaceAll(layout, "{arch}", arch)
+		}
+
+		matches, _ := filepath.Glob(filepath.Join(artifact.Dir, pattern))
+		if len(matches) != 1 {
+			return []Result{{Check: "layout", Err: fmt.Errorf("expected one %s, found %d", pattern, len(matches))}}
+		}
+		binary := matches[0]
+
+		results := []Result{{Check: "layout"}}
+
+		info, err := os.Stat(binary)
+		if err == nil && info.Mode().Perm()&0o111 == 0 {
+			err = fmt.Errorf("%s is not executable", pattern)
+		}
+		results = append(results, Result{Check: "executable", Err: err})
+		if err != nil {
+			return results
+		}
+
+		if artifact.Arch != "" && artifact.Arch != runtime.GOARCH {
+			return results
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		output, err := exec.CommandContext(ctx, binary, "--version").CombinedOutput()
+		switch {
+		case errors.Is(err, syscall.ENOEXEC):
+			// The binary is built for another architecture
+			return results
+		case err != nil:
+			err = fmt.Errorf("%s --version failed: %w: %s", name, err, bytes.TrimSpace(output))
+		case !containsVersion(string(output), version):
+			err = fmt.Errorf("expected version %s, got %q", version, strings.TrimSpace(string(output)))
+		}
+
+		return append(results, Result{Check: "version", Err: err})
+	}
+}
+
+// checkPythonPackage checks the artifacts of the python tools: either the
+// source distribution of the tool, along with the ones of its build
+// dependencies, or a wheelhouse, see dependency/actions/compile.
+func checkPythonPackage(name string) func(Artifact, string) []Result {
+	return func(artifact Artifact, version string) []Result {
+		// The packages are published with the PEP 440 form of the version
+		version = pep440.FromSemver(version)
+
+		requirements := filepath.Join(artifact.Dir, "requirements.txt")
+		if _, err := os.Stat(requirements); err == nil {
+			return checkWheelhouse(artifact.Dir, name, version)
+		}
+
+		// The source distribution is either unpacked at the root of the
+		// artifact or in a directory named after the package
+		for _, pkgInfo := range []string{"PKG-INFO", fmt.Sprintf("%s-*/PKG-INFO", name)} {
+			matches, _ := filepath.Glob(filepath.Join(artifact.Dir, pkgInfo))
+			if len(matches) == 1 {
+				actual, err := pkgInfoVersion(matches[0])
+				if err == nil && !sameVersion(actual, version) {
+					err = fmt.Errorf("expected version %s, got %s", version, actual)
+				}
+				return []Result{{Check: "layout"}, {Check: "version", Err: err}}
+			}
+		}
+
+		return []Result{{Check: "layout", Err: errors.New("neither a source distribution nor a wheelhouse")}}
+	}
+}
+
+func pkgInfoVersion(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		if version, ok := strings.CutPrefix(line, "Version:"); ok {
+			return strings.TrimSpace(version), nil
+		}
+	}
+	return "", fmt.Errorf("no version in %s", filepath.Base(path))
+}
+
+// checkWheelhouse checks the wheels of a wheelhouse against the hashes of
+// its requirements.txt, one of them being the tool at the expected version.
+func checkWheelhouse(dir, name, version string) []Result {
+	content, err := os.ReadFile(filepath.Join(dir, "requirements.txt"))
+	if err != nil {
+		return []Result{{Check: "layout", Err: err}}
+	}
+
+	results := []Result{{Check: "layout"}}
+	toolFound := false
+
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		requirement, hash, _ := strings.Cut(line, " --hash=sha256:")
+		wheelName, wheelVersion, ok := strings.Cut(requirement, "==")
+		if !ok || hash == "" {
+			results = append(results, Result{Check: "hashes", Err: fmt.Errorf("invalid requirement %q", line)})
+			continue
+		}
+
+		if normalizeName(wheelName) == normalizeName(name) {
+			toolFound = true
+			if !sameVersion(wheelVersion, version) {
+				results = append(results, Result{Check: "version", Err: fmt.Errorf("expected version %s, got %s", version, wheelVersion)})
+			}
+		}
+
+		wheels, _ := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%s-%s-*.whl", wheelName, wheelVersion)))
+		if len(wheels) != 1 {
+			results = append(results, Result{Check: "hashes", Err: fmt.Errorf("expected one wheel for %s, found %d", requirement, len(wheels))})
+			continue
+		}
+
+		actual, err := fileSHA256(wheels[0])
+		if err == nil && actual != hash {
+			err = fmt.Errorf("hash of %s does not match requirements.txt", filepath.Base(wheels[0]))
+		}
+		if err != nil {
+			results = append(results, Result{Check: "hashes", Err: err})
+		}
+	}
+
+	if !toolFound {
+		results = append(results, Result{Check: "version", Err: fmt.Errorf("no wheel of %s in the wheelhouse", name)})
+	}
+
+	// Report the checks which passed once
+	for _, check := range []string{"hashes", "version"} {
+		failed := false
+		for _, result := range results {
+			failed = failed || (result.Check == check && result.Err != nil)
+		}
+		if !failed {
+			results = append(results, Result{Check: check})
+		}
+	}
+
+	return results
+}
+
+// checkInstaller checks a Miniconda installer: a shell script whose header
+// gives the number of lines of the script and the MD5 of the payload
+// following them.
+func checkInstaller(artifact Artifact, version string) []Result {
+	content, err := os.ReadFile(artifact.Path)
+	if err != nil {
+		return []Result{{Check: "layout", Err: err}}
+	}
+
+	if !bytes.HasPrefix(content, []byte("#!")) {
+		return []Result{{Check: "layout", Err: errors.New("not a shell script")}}
+	}
+
+	header := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for i := 0; i < 32 && scanner.Scan(); i++ {
+		if key, value, ok := strings.Cut(strings.TrimPrefix(scanner.Text(), "#"), ":"); ok {
+			header[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	lines, err := strconv.Atoi(header["LINES"])
+	if err != nil || header["MD5"] == "" {
+		return []Result{{Check: "layout", Err: errors.New("no LINES and MD5 in the installer header")}}
+	}
+
+	results := []Result{{Check: "layout"}}
+
+	// The payload starts at line LINES, as extracted with tail -n +LINES
+	payload := content
+	for i := 1; i < lines && len(payload) > 0; i++ {
+		index := bytes.IndexByte(payload, '\n')
+		if index < 0 {
+			payload = nil
+			break
+		}
+		payload = payload[index+1:]
+	}
+
+	var payloadErr error
+	sum := md5.Sum(payload)
+	if len(payload) == 0 {
+		payloadErr = errors.New("no payload after the script")
+	} else if actual := hex.EncodeToString(sum[:]); actual != header["MD5"] {
+		payloadErr = fmt.Errorf("payload MD5 %s does not match the header %s", actual, header["MD5"])
+	}
+	results = append(results, Result{Check: "payload", Err: payloadErr})
+
+	var versionErr error
+	if !containsVersion(header["VER"], version) {
+		versionErr = fmt.Errorf("expected version %s, got %q", version, header["VER"])
+	}
+
+	return append(results, Result{Check: "version", Err: versionErr})
+}
+
+// containsVersion returns whether text mentions version as a whole, e.g.
+// "uv 0.10.11 (x86_64)" or "py39_25.3.1-1" for 25.3.1 but not 25.3.10.
+func containsVersion(text, version string) bool {
+	for index := strings.Index(text, version); index >= 0; {
+		end := index + len(version)
+		before := index == 0 || !isVersionChar(text[index-1])
+		after := end == len(text) || !isVersionChar(text[end])
+		if before && after {
+			return true
+		}
+
+		next := strings.Index(text[index+1:], version)
+		if next < 0 {
+			break
+		}
+		index += next + 1
+	}
+	return false
+}
+
+func isVersionChar(c byte) bool {
+	return c == '.' || (c >= '0' && c <= '9')
+}
+
+// sameVersion compares versions ignoring their trailing zeros, as 2.3 and
+// 2.3.0 are the same python version.
+func sameVersion(a, b string) bool {
+	trim := func(version string) string {
+		for strings.HasSuffix(version, ".0") {
+			version = strings.TrimSuffix(version, ".0")
+		}
+		return version
+	}
+	return trim(a) == trim(b)
+}
+
+// normalizeName normalizes python package names, which wheels spell with
+// underscores.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
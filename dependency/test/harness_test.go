@@ -0,0 +1,297 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+)
+
+type file struct {
+	name    string
+	content string
+	mode    int64
+}
+
+func testCheck(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		dir string
+	)
+
+	it.Before(func() {
+		dir = t.TempDir()
+	})
+
+	writeTarball := func(name string, files ...file) string {
+		buffer := bytes.NewBuffer(nil)
+		gzipWriter := gzip.NewWriter(buffer)
+		tarWriter := tar.NewWriter(gzipWriter)
+
+		for _, f := range files {
+			mode := f.mode
+			if mode == 0 {
+				mode = 0o644
+			}
+			Expect(tarWriter.WriteHeader(&tar.Header{
+				Name:     f.name,
+				Mode:     mode,
+				Size:     int64(len(f.content)),
+				Typeflag: tar.TypeReg,
+			})).To(Succeed())
+			_, err := tarWriter.Write([]byte(f.content))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(tarWriter.Close()).To(Succeed())
+		Expect(gzipWriter.Close()).To(Succeed())
+
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, buffer.Bytes(), 0o644)).To(Succeed())
+		return path
+	}
+
+	failures := func(results []Result) []string {
+		var failures []string
+		for _, result := range results {
+			if result.Err != nil {
+				failures = append(failures, result.String())
+			}
+		}
+		return failures
+	}
+
+	checks := func(results []Result) []string {
+		var checks []string
+		for _, result := range results {
+			checks = append(checks, result.Check)
+		}
+		return checks
+	}
+
+	it("fails on unknown dependencies", func() {
+		_, err := Check("cpython", "cpython.tgz", "3.14.0", "")
+		Expect(err).To(MatchError(`no checks for dependency "cpython"`))
+	})
+
+	it("reports archives which cannot be unpacked", func() {
+		path := filepath.Join(dir, "uv.tar.gz")
+		Expect(os.WriteFile(path, []byte("not a tarball"), 0o644)).To(Succeed())
+
+		results, err := Check("uv", path, "0.10.11", "amd64")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failures(results)).To(HaveLen(1))
+		Expect(results[0].Check).To(Equal("unpack"))
+	})
+
+	it("rejects archives escaping their directory", func() {
+		path := writeTarball("pip.tgz", file{name: "../PKG-INFO", content: "Version: 26.0.1\n"})
+
+		results, err := Check("pip", path, "26.0.1", "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failures(results)).To(ConsistOf(ContainSubstring("outside of the archive")))
+	})
+
+	context("binaries", func() {
+		var arch string
+
+		it.Before(func() {
+			arch = runtime.GOARCH
+			if _, ok := archNames[arch]; !ok {
+				t.Skipf("no artifacts for %s", arch)
+			}
+		})
+
+		script := func(output string) string {
+			return fmt.Sprintf("#!/bin/sh\necho %q\n", output)
+		}
+
+		it("checks the layout of uv and runs it", func() {
+			path := writeTarball("uv.tar.gz", file{
+				name:    fmt.Sprintf("uv-%s-unknown-linux-gnu/uv", archNames[arch]),
+				content: script("uv 0.10.11 (f7f4e8b 2026-03-10)"),
+				mode:    0o755,
+			})
+
+			results, err := Check("uv", path, "0.10.11", arch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(BeEmpty())
+			Expect(checks(results)).To(Equal([]string{"layout", "executable", "version"}))
+		})
+
+		it("reports a version mismatch", func() {
+			path := writeTarball("pixi.tar.gz", file{name: "pixi", content: script("pixi 0.66.0"), mode: 0o755})
+
+			results, err := Check("pixi", path, "0.66.1", arch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{`FAIL version: expected version 0.66.1, got "pixi 0.66.0"`}))
+		})
+
+		it("reports a missing or non executable binary", func() {
+			path := writeTarball("uv.tar.gz", file{name: "uv-riscv64gc-unknown-linux-gnu/uv", content: script("uv 0.10.11"), mode: 0o755})
+
+			results, err := Check("uv", path, "0.10.11", arch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(ConsistOf(ContainSubstring("expected one uv-%s-unknown-linux-gnu/uv, found 0", archNames[arch])))
+
+			path = writeTarball("pixi.tar.gz", file{name: "pixi", content: script("pixi 0.66.0")})
+
+			results, err = Check("pixi", path, "0.66.0", arch)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{"FAIL executable: pixi is not executable"}))
+		})
+
+		it("does not run binaries of another architecture", func() {
+			other := "arm64"
+			if arch == other {
+				other = "amd64"
+			}
+			path := writeTarball("uv.tar.gz", file{
+				name:    fmt.Sprintf("uv-%s-unknown-linux-gnu/uv", archNames[other]),
+				content: script("uv 0.0.1"),
+				mode:    0o755,
+			})
+
+			results, err := Check("uv", path, "0.10.11", other)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(BeEmpty())
+			Expect(checks(results)).To(Equal([]string{"layout", "executable"}))
+		})
+	})
+
+	context("python packages", func() {
+		it("checks the version of source distributions", func() {
+			path := writeTarball("pip.tgz",
+				file{name: "PKG-INFO", content: "Metadata-Version: 2.1\nName: pip\nVersion: 26.0\n"},
+				file{name: "setuptools-80.9.0.tar.gz", content: "sdist"},
+			)
+
+			results, err := Check("pip", path, "26.0.0", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(BeEmpty())
+
+			results, err = Check("pip", path, "26.0.1", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{"FAIL version: expected version 26.0.1, got 26.0"}))
+		})
+
+		it("checks post releases against their PEP 440 version", func() {
+			path := writeTarball("pipenv.tgz", file{name: "pipenv-2024.0.1.post1/PKG-INFO", content: "Version: 2024.0.1.post1\n"})
+
+			results, err := Check("pipenv", path, "2024.0.1+post1", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(BeEmpty())
+		})
+
+		it("checks source distributions used as published", func() {
+			path := writeTarball("poetry-2.3.2.tar.gz", file{name: "poetry-2.3.2/PKG-INFO", content: "Version: 2.3.2\n"})
+
+			results, err := Check("poetry", path, "2.3.2", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(BeEmpty())
+		})
+
+		it("checks the hashes of wheelhouses", func() {
+			poetry := "poetry wheel"
+			core := "poetry-core wheel"
+			hash := func(content string) string {
+				sum := sha256.Sum256([]byte(content))
+				return hex.EncodeToString(sum[:])
+			}
+
+			path := writeTarball("poetry.tgz",
+				file{name: "poetry-2.3.2-py3-none-any.whl", content: poetry},
+				file{name: "poetry_core-2.3.1-py3-none-any.whl", content: core},
+				file{name: "requirements.txt", content: fmt.Sprintf(
+					"poetry==2.3.2 --hash=sha256:%s\npoetry_core==2.3.1 --hash=sha256:%s\n", hash(poetry), hash("tampered"))},
+			)
+
+			results, err := Check("poetry-cp312", path, "2.3.2", "amd64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{
+				"FAIL hashes: hash of poetry_core-2.3.1-py3-none-any.whl does not match requirements.txt",
+			}))
+			Expect(checks(results)).To(ConsistOf("layout", "hashes", "version"))
+
+			results, err = Check("poetry-cp312", path, "2.3.3", "amd64")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(ContainElement("FAIL version: expected version 2.3.3, got 2.3.2"))
+		})
+
+		it("reports unknown layouts", func() {
+			path := writeTarball("pipenv.tgz", file{name: "README.md", content: "pipenv"})
+
+			results, err := Check("pipenv", path, "2026.0.3", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{"FAIL layout: neither a source distribution nor a wheelhouse"}))
+		})
+	})
+
+	context("installers", func() {
+		writeInstaller := func(version, payload string, corrupt bool) string {
+			sum := md5.Sum([]byte(payload))
+			if corrupt {
+				sum = md5.Sum([]byte("other"))
+			}
+
+			script := fmt.Sprintf("#!/bin/sh\n#\n# NAME:  Miniconda3\n# VER:   %s\n# PLAT:  linux-64\n# LINES: 9\n# MD5:   %s\nexit 0\n%s",
+				version, hex.EncodeToString(sum[:]), payload)
+
+			path := filepath.Join(dir, "Miniconda3.sh")
+			Expect(os.WriteFile(path, []byte(script), 0o755)).To(Succeed())
+			return path
+		}
+
+		it("checks the payload and version of Miniconda", func() {
+			path := writeInstaller("py39_25.3.1-1", "payload\x00\x01", false)
+
+			results, err := Check("miniconda3", path, "25.3.1", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(BeEmpty())
+			Expect(checks(results)).To(Equal([]string{"layout", "payload", "version"}))
+
+			results, err = Check("miniconda3", path, "25.3.10", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{`FAIL version: expected version 25.3.10, got "py39_25.3.1-1"`}))
+		})
+
+		it("reports corrupted or missing payloads", func() {
+			path := writeInstaller("py39_25.3.1-1", "payload", true)
+
+			results, err := Check("miniconda3", path, "25.3.1", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(ConsistOf(ContainSubstring("does not match the header")))
+
+			path = writeInstaller("py39_25.3.1-1", "", false)
+
+			results, err = Check("miniconda3", path, "25.3.1", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{"FAIL payload: no payload after the script"}))
+		})
+
+		it("rejects files which are not installers", func() {
+			path := writeTarball("Miniconda3.sh", file{name: "conda", content: "conda"})
+
+			results, err := Check("miniconda3", path, "25.3.1", "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(failures(results)).To(Equal([]string{"FAIL layout: not a shell script"}))
+		})
+	})
+}
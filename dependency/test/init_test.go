@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitTest(t *testing.T) {
+	suite := spec.New("test", spec.Report(report.Terminal{}))
+	suite("Check", testCheck)
+	suite.Run(t)
+}
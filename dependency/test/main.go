@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command test checks a dependency artifact, as compiled or downloaded from
+// upstream, before it is added to buildpack.toml: its layout, its content
+// and its version. It runs offline.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// writeReport writes the results and returns the number of failed checks.
+func writeReport(writer io.Writer, results []Result) (int, error) {
+	failures := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failures++
+		}
+		if _, err := fmt.Fprintln(writer, result); err != nil {
+			return 0, err
+		}
+	}
+	return failures, nil
+}
+
+func main() {
+	var (
+		id          string
+		tarballPath string
+		version     string
+		arch        string
+	)
+	flag.StringVar(&id, "id", "", "id of the dependency, e.g. uv")
+	flag.StringVar(&tarballPath, "tarball-path", "", "path to the artifact to test")
+	flag.StringVar(&version, "expected-version", "", "version of the dependency the artifact should hold")
+	flag.StringVar(&arch, "arch", "", "architecture of the artifact, e.g. amd64")
+	flag.Parse()
+
+	if id == "" || tarballPath == "" || version == "" {
+		fmt.Fprintln(os.Stderr, "Error: --id, --tarball-path and --expected-version are required")
+		os.Exit(1)
+	}
+
+	results, err := Check(id, tarballPath, version, arch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	failures, err := writeReport(os.Stdout, results)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	if failures > 0 {
+		fmt.Fprintf(os.Stderr, "%s: %d of %d checks failed\n", tarballPath, failures, len(results))
+		os.Exit(1)
+	}
+
+	fmt.Println("All tests passed!")
+}
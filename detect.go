@@ -6,6 +6,11 @@
 package pythoninstallers
 
 import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
 	"github.com/paketo-buildpacks/packit/v2"
 	"github.com/paketo-buildpacks/packit/v2/scribe"
 
@@ -15,67 +20,87 @@ import (
 	pixi "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pixi"
 	poetry "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry"
 	uv "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/uv"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 )
 
 // Detect will return a packit.DetectFunc that will be invoked during the
 // detect phase of the buildpack lifecycle.
 //
 // If this buildpack detects files that indicate your app is a Python project,
-// it will pass detection.
-func Detect(logger scribe.Emitter, pyProjectParser poetry.PyProjectParser) packit.DetectFunc {
+// it will pass detection. The package managers considered can be restricted
+// using BP_PYTHON_PACKAGE_MANAGERS.
+//
+// The project files are loaded once and shared by the detectors of all the
+// package managers.
+func Detect(logger scribe.Emitter, loader project.Loader) packit.DetectFunc {
 	return func(context packit.DetectContext) (packit.DetectResult, error) {
-		plans := []packit.BuildPlan{}
-
-		pipResult, err := pip.Detect()(context)
-
-		if err == nil {
-			plans = append(plans, pipResult.Plan)
-		} else {
-			logger.Detail("%s", err)
+		selection, err := ParsePackageManagerSelection(os.Getenv(EnvPackageManagers))
+		if err != nil {
+			return packit.DetectResult{}, err
 		}
 
-		minicondaResult, err := miniconda.Detect()(context)
+		projectLoader := project.NewCachedLoader(loader)
 
-		if err == nil {
-			plans = append(plans, minicondaResult.Plan)
-		} else {
-			logger.Detail("%s", err)
+		detectors := map[string]packit.DetectFunc{
+			pip.Pip:                 pip.Detect(projectLoader, logger),
+			miniconda.Conda:         miniconda.Detect(),
+			pipenv.Pipenv:           pipenv.Detect(projectLoader, logger),
+			poetry.PoetryDependency: poetry.Detect(projectLoader, logger),
+			uv.Uv:                   uv.Detect(projectLoader, logger),
+			pixi.Pixi:               pixi.Detect(projectLoader, logger),
 		}
 
-		pipenvResult, err := pipenv.Detect()(context)
-
-		if err == nil {
-			plans = append(plans, pipenvResult.Plan)
-		} else {
-			logger.Detail("%s", err)
+		plans := []packit.BuildPlan{}
+		detected := []string{}
+
+		for _, name := range PackageManagers {
+			if !selection.Enabled(name) {
+				logger.Detail("%s is disabled by %s", name, EnvPackageManagers)
+				continue
+			}
+
+			result, err := detectors[name](context)
+
+			if err == nil {
+				plans = append(plans, result.Plan)
+				detected = append(detected, name)
+			} else {
+				logger.Detail("%s", err)
+			}
 		}
 
-		poetryResult, err := poetry.Detect(pyProjectParser)(context)
-
-		if err == nil {
-			plans = append(plans, poetryResult.Plan)
-		} else {
-			logger.Detail("%s", err)
+		if len(plans) == 0 {
+			return packit.DetectResult{}, packit.Fail.WithMessage("No python packager manager related files found")
 		}
 
-		uvResult, err := uv.Detect()(context)
-
-		if err == nil {
-			plans = append(plans, uvResult.Plan)
-		} else {
-			logger.Detail("%s", err)
+		proj, err := projectLoader.Load(context.WorkingDir)
+		if err != nil {
+			return packit.DetectResult{}, err
 		}
 
-		pixiResult, err := pixi.Detect()(context)
+		lockfileOwners, err := FindLockfiles(proj.Path, selection)
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
 
-		if err == nil {
-			plans = append(plans, pixiResult.Plan)
-		} else {
-			logger.Detail("%s", err)
+		var conflicting []string
+		for _, name := range lockfileOwners {
+			if slices.Contains(detected, name) {
+				conflicting = append(conflicting, name)
+			}
 		}
 
-		if len(plans) == 0 {
-			return packit.DetectResult{}, packit.Fail.WithMessage("No python packager manager related files found")
+		if len(conflicting) > 1 {
+			var lockfiles []string
+			for _, name := range conflicting {
+				lockfiles = append(lockfiles, fmt.Sprintf("%s (%s)", Lockfiles[name], name))
+			}
+
+			logger.Process("Warning: lock files of multiple package managers found: %s", strings.Join(lockfiles, ", "))
+			logger.Subprocess("The build plans are offered in the order %s.", strings.Join(conflicting, ", "))
+			logger.Subprocess("%s is used if a later buildpack requires it, the others only serve as fallbacks.", conflicting[0])
+			logger.Subprocess("Set %s to select the package manager explicitly, e.g. %s=%s", EnvPackageManagers, EnvPackageManagers, conflicting[0])
+			logger.Break()
 		}
 
 		return packit.DetectResult{
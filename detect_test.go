@@ -22,8 +22,8 @@ import (
 	pipenv "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pipenv"
 	pixi "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pixi"
 	poetry "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry"
-	poetryfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry/fakes"
 	uv "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/uv"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 
 	"github.com/sclevine/spec"
 
@@ -37,8 +37,6 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		workingDir string
 		buffer     *bytes.Buffer
 
-		parsePoetryProject *poetryfakes.PoetryPyProjectParser
-
 		detect packit.DetectFunc
 
 		plans []packit.BuildPlan
@@ -48,15 +46,13 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		workingDir = t.TempDir()
 
 		Expect(os.WriteFile(filepath.Join(workingDir, "x.py"), []byte{}, os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(workingDir, "requirements.txt"), []byte{}, os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte{}, os.ModePerm)).To(Succeed())
 
 		buffer = bytes.NewBuffer(nil)
 		logger := scribe.NewEmitter(buffer)
 
-		parsePoetryProject = &poetryfakes.PoetryPyProjectParser{}
-		parsePoetryProject.ParsePythonVersionCall.Returns.String = "1.2.3"
-		parsePoetryProject.IsPoetryProjectCall.Returns.Bool = true
-
-		detect = pythoninstallers.Detect(logger, parsePoetryProject)
+		detect = pythoninstallers.Detect(logger, project.NewLoader())
 
 		plans = append(plans, packit.BuildPlan{
 			Provides: []packit.BuildPlanProvision{
@@ -121,10 +117,45 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("without pip and pipenv project files", func() {
+			it.Before(func() {
+				Expect(os.Remove(filepath.Join(workingDir, "requirements.txt"))).To(Succeed())
+				Expect(os.Remove(filepath.Join(workingDir, "Pipfile"))).To(Succeed())
+			})
+
+			it("only offers conda", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan).To(Equal(plans[1]))
+			})
+
+			context("when BP_PYTHON_INSTALLERS_ALWAYS_DETECT is set", func() {
+				it.Before(func() {
+					t.Setenv(pip.EnvAlwaysDetect, "true")
+				})
+
+				it("offers pip and pipenv as well", func() {
+					result, err := detect(packit.DetectContext{
+						WorkingDir: workingDir,
+					})
+
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Plan).To(Equal(pythoninstallers.Or(plans...)))
+				})
+			})
+		})
+
 		context("with pyproject.toml", func() {
 			context("without build backend", func() {
 				it.Before(func() {
-					Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte{}, os.ModePerm)).To(Succeed())
+					content := []byte(`
+					[tool.poetry.dependencies]
+					python = "1.2.3"
+					`)
+					Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), content, os.ModePerm)).To(Succeed())
 				})
 
 				it("passes detection", func() {
@@ -168,9 +199,11 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 					[build-system]
 					requires = ["poetry-core>=1.0.0"]
 					build-backend = "poetry.core.masonry.api"
+
+					[tool.poetry.dependencies]
+					python = "1.2.3"
 					`)
 					Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), content, os.ModePerm)).To(Succeed())
-					parsePoetryProject.IsPoetryProjectCall.Returns.Bool = true
 				})
 
 				it("passes detection", func() {
@@ -216,7 +249,6 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 					build-backend = "setuptools.build_meta"
 					`)
 					Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), content, os.ModePerm)).To(Succeed())
-					parsePoetryProject.IsPoetryProjectCall.Returns.Bool = false
 				})
 
 				it("passes detection", func() {
@@ -274,5 +306,115 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 				Expect(result.Plan).To(Equal(pythoninstallers.Or(withUv...)))
 			})
 		})
+
+		context("with a malformed pixi.toml", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, "pixi.toml"), []byte("%%%"), os.ModePerm)).To(Succeed())
+			})
+
+			it("still offers the plans of the other package managers", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan).To(Equal(pythoninstallers.Or(plans...)))
+				Expect(buffer.String()).To(ContainSubstring("failed to parse pixi.toml"))
+			})
+		})
+
+		context("with lock files of multiple package managers", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, uv.LockfileName), []byte(`requires-python = "3.13.0"`), os.ModePerm)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, pixi.LockfileName), []byte(""), os.ModePerm)).To(Succeed())
+			})
+
+			it("warns about the conflict and explains which plan wins", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan.Or).To(HaveLen(4))
+
+				Expect(buffer.String()).To(ContainSubstring("Warning: lock files of multiple package managers found: uv.lock (uv), pixi.lock (pixi)"))
+				Expect(buffer.String()).To(ContainSubstring("The build plans are offered in the order uv, pixi."))
+				Expect(buffer.String()).To(ContainSubstring("uv is used if a later buildpack requires it, the others only serve as fallbacks."))
+				Expect(buffer.String()).To(ContainSubstring("Set BP_PYTHON_PACKAGE_MANAGERS to select the package manager explicitly, e.g. BP_PYTHON_PACKAGE_MANAGERS=uv"))
+			})
+
+			context("when one of them is excluded", func() {
+				it.Before(func() {
+					t.Setenv("BP_PYTHON_PACKAGE_MANAGERS", "!pixi")
+				})
+
+				it("does not warn", func() {
+					_, err := detect(packit.DetectContext{
+						WorkingDir: workingDir,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(buffer.String()).NotTo(ContainSubstring("Warning"))
+					Expect(buffer.String()).To(ContainSubstring("pixi is disabled by BP_PYTHON_PACKAGE_MANAGERS"))
+				})
+			})
+		})
+
+		context("when BP_PYTHON_PACKAGE_MANAGERS is an allow list", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, uv.LockfileName), []byte(`requires-python = "3.13.0"`), os.ModePerm)).To(Succeed())
+				t.Setenv("BP_PYTHON_PACKAGE_MANAGERS", "uv")
+			})
+
+			it("only offers the plans of the allowed package managers", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan).To(Equal(packit.BuildPlan{
+					Provides: []packit.BuildPlanProvision{
+						{Name: uv.Uv},
+					},
+				}))
+			})
+		})
+
+		context("when BP_PYTHON_PACKAGE_MANAGERS is a deny list", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PACKAGE_MANAGERS", "!pip,!conda")
+			})
+
+			it("does not offer the plans of the excluded package managers", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan).To(Equal(pythoninstallers.Or(plans[2:]...)))
+			})
+		})
+
+		context("when BP_PYTHON_PACKAGE_MANAGERS only allows undetected package managers", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PACKAGE_MANAGERS", "uv")
+			})
+
+			it("fails detection", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(packit.Fail.WithMessage("No python packager manager related files found")))
+			})
+		})
+
+		context("when BP_PYTHON_PACKAGE_MANAGERS is invalid", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PACKAGE_MANAGERS", "hatch")
+			})
+
+			it("returns an error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring(`unknown package manager "hatch"`)))
+			})
+		})
 	})
 }
@@ -15,6 +15,7 @@ import (
 func TestUnitPythonInstallers(t *testing.T) {
 	suite := spec.New("python-package-managers-install", spec.Report(report.Terminal{}), spec.Sequential())
 	suite("Detect", testDetect)
+	suite("PackageManagers", testPackageManagers)
 	suite("Build", testBuild)
 	suite.Run(t)
 }
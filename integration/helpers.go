@@ -8,9 +8,13 @@ package integration_helpers
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/paketo-buildpacks/occam"
+
+	. "github.com/onsi/gomega"
 )
 
 type Buildpack struct {
@@ -67,6 +71,19 @@ func DependenciesForId(dependencies []Dependency, id string) []Dependency {
 	return output
 }
 
+// ExpectLayerSBOM checks that the SBOM of the launch layer of the buildpack
+// was written to sbomDir in each of the formats it generates.
+func ExpectLayerSBOM(t *testing.T, sbomDir string, buildpackID string, layer string) {
+	t.Helper()
+
+	Expect := NewWithT(t).Expect
+
+	layerDir := filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackID, "/", "_"), layer)
+	for _, file := range []string{"sbom.cdx.json", "sbom.spdx.json", "sbom.syft.json"} {
+		Expect(filepath.Join(layerDir, file)).To(BeARegularFile())
+	}
+}
+
 func NewRetryBuild(t *testing.T, retry int) RetryBuild {
 	return RetryBuild{t, retry}
 }
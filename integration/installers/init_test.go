@@ -120,5 +120,8 @@ func TestIntegration(t *testing.T) {
 	suite("pixi LayerReuse", pixiTestLayerReuse, spec.Parallel())
 	suite("pixi Offline", pixiTestOffline, spec.Parallel())
 
+	// Project path
+	suite("Project path", projectPathTest, spec.Parallel())
+
 	suite.Run(t)
 }
@@ -86,7 +86,7 @@ func minicondaTestDefault(t *testing.T, context spec.G, it spec.S) {
 				"      <unknown> -> \"\"",
 			))
 			Expect(logs).To(ContainLines(
-				MatchRegexp(`    Selected Miniconda.sh version \(using <unknown>\): \d+\.\d+\.\d+`),
+				MatchRegexp(`    Selected Miniconda.sh version \(using latest available\): \d+\.\d+\.\d+`),
 			))
 			Expect(logs).To(ContainLines(
 				"  Executing build process",
@@ -192,9 +192,7 @@ func minicondaTestDefault(t *testing.T, context spec.G, it spec.S) {
 				}).Should(ContainSubstring(`"name":"Miniconda.sh"`))
 
 				// check that all required SBOM files are present
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "conda", "sbom.cdx.json")).To(BeARegularFile())
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "conda", "sbom.spdx.json")).To(BeARegularFile())
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "conda", "sbom.syft.json")).To(BeARegularFile())
+				integration_helpers.ExpectLayerSBOM(t, sbomDir, buildpackInfo.Buildpack.ID, "conda")
 
 				// check an SBOM file to make sure it has an entry for cpython
 				contents, err := os.ReadFile(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "conda", "sbom.cdx.json"))
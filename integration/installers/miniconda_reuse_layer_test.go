@@ -33,8 +33,9 @@ func minicondaTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 		imageIDs     map[string]struct{}
 		containerIDs map[string]struct{}
 
-		name   string
-		source string
+		name    string
+		source  string
+		sbomDir string
 	)
 
 	it.Before(func() {
@@ -50,6 +51,10 @@ func minicondaTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 
 		source, err = occam.Source(filepath.Join("testdata", "conda", "miniconda_app"))
 		Expect(err).NotTo(HaveOccurred())
+
+		sbomDir, err = os.MkdirTemp("", "sbom")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chmod(sbomDir, os.ModePerm)).To(Succeed())
 	})
 
 	it.After(func() {
@@ -64,6 +69,7 @@ func minicondaTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 		Expect(docker.Volume.Remove.Execute(occam.CacheVolumeNames(name))).To(Succeed())
 
 		Expect(os.RemoveAll(source)).To(Succeed())
+		Expect(os.RemoveAll(sbomDir)).To(Succeed())
 	})
 
 	context("when the app is rebuilt and the same conda version is required", func() {
@@ -100,6 +106,7 @@ func minicondaTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 
 			secondImage, logs, err = retryBuild.Execute(pack.WithNoColor().Build.
 				WithPullPolicy("never").
+				WithSBOMOutputDir(sbomDir).
 				WithBuildpacks(
 					settings.Buildpacks.PythonInstallers.Online,
 					settings.Buildpacks.BuildPlan.Online,
@@ -118,12 +125,15 @@ func minicondaTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 				"      <unknown> -> \"\"",
 			))
 			Expect(logs).To(ContainLines(
-				MatchRegexp(`    Selected Miniconda.sh version \(using <unknown>\): \d+\.\d+\.\d+`),
+				MatchRegexp(`    Selected Miniconda.sh version \(using latest available\): \d+\.\d+\.\d+`),
 			))
 			Expect(logs).To(ContainLines(
 				fmt.Sprintf("  Reusing cached layer /layers/%s/conda", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_")),
 			))
 
+			// check that the SBOM of the reused layer is still written
+			integration_helpers.ExpectLayerSBOM(t, sbomDir, buildpackInfo.Buildpack.ID, "conda")
+
 			secondContainer, err = docker.Container.Run.
 				WithCommand("conda --version").
 				Execute(secondImage.ID)
@@ -16,6 +16,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	. "github.com/paketo-buildpacks/occam/matchers"
+
+	integration_helpers "github.com/paketo-buildpacks/python-package-managers-install/integration"
 )
 
 func pipTestDefault(t *testing.T, context spec.G, it spec.S) {
@@ -81,7 +83,7 @@ func pipTestDefault(t *testing.T, context spec.G, it spec.S) {
 				"      <unknown> -> \"\"",
 			))
 			Expect(logs).To(ContainLines(
-				MatchRegexp(`    Selected Pip version \(using <unknown>\): \d+\.\d+\.\d+`),
+				MatchRegexp(`    Selected Pip version \(using latest available\): \d+\.\d+\.\d+`),
 			))
 			Expect(logs).To(ContainLines(
 				"  Executing build process",
@@ -183,9 +185,7 @@ func pipTestDefault(t *testing.T, context spec.G, it spec.S) {
 				}).Should(ContainSubstring(`"name":"Pip"`))
 
 				// check that all required SBOM files are present
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pip", "sbom.cdx.json")).To(BeARegularFile())
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pip", "sbom.spdx.json")).To(BeARegularFile())
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pip", "sbom.syft.json")).To(BeARegularFile())
+				integration_helpers.ExpectLayerSBOM(t, sbomDir, buildpackInfo.Buildpack.ID, "pip")
 
 				// check an SBOM file to make sure it has an entry for cpython
 				contents, err := os.ReadFile(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pip", "sbom.cdx.json"))
@@ -16,6 +16,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	. "github.com/paketo-buildpacks/occam/matchers"
+
+	integration_helpers "github.com/paketo-buildpacks/python-package-managers-install/integration"
 )
 
 func pixiTestDefault(t *testing.T, context spec.G, it spec.S) {
@@ -80,7 +82,7 @@ func pixiTestDefault(t *testing.T, context spec.G, it spec.S) {
 				"      <unknown> -> \"\"",
 			))
 			Expect(logs).To(ContainLines(
-				MatchRegexp(`    Selected pixi version \(using <unknown>\): \d+\.\d+\.\d+`),
+				MatchRegexp(`    Selected pixi version \(using latest available\): \d+\.\d+\.\d+`),
 			))
 			Expect(logs).To(ContainLines(
 				"  Executing build process",
@@ -169,9 +171,7 @@ func pixiTestDefault(t *testing.T, context spec.G, it spec.S) {
 				}).Should(ContainSubstring(`"name":"pixi"`))
 
 				// check that all required SBOM files are present
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pixi", "sbom.cdx.json")).To(BeARegularFile())
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pixi", "sbom.spdx.json")).To(BeARegularFile())
-				Expect(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pixi", "sbom.syft.json")).To(BeARegularFile())
+				integration_helpers.ExpectLayerSBOM(t, sbomDir, buildpackInfo.Buildpack.ID, "pixi")
 
 				// check an SBOM file to make sure it has an entry for cpython
 				contents, err := os.ReadFile(filepath.Join(sbomDir, "sbom", "launch", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_"), "pixi", "sbom.cdx.json"))
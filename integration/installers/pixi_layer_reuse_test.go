@@ -32,8 +32,9 @@ func pixiTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 		imageIDs     map[string]struct{}
 		containerIDs map[string]struct{}
 
-		name   string
-		source string
+		name    string
+		source  string
+		sbomDir string
 	)
 
 	it.Before(func() {
@@ -49,6 +50,10 @@ func pixiTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 
 		source, err = occam.Source(filepath.Join("testdata", "pixi", "pixi_app"))
 		Expect(err).NotTo(HaveOccurred())
+
+		sbomDir, err = os.MkdirTemp("", "sbom")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chmod(sbomDir, os.ModePerm)).To(Succeed())
 	})
 
 	it.After(func() {
@@ -63,6 +68,7 @@ func pixiTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 		Expect(docker.Volume.Remove.Execute(occam.CacheVolumeNames(name))).To(Succeed())
 
 		Expect(os.RemoveAll(source)).To(Succeed())
+		Expect(os.RemoveAll(sbomDir)).To(Succeed())
 	})
 
 	context("when the app is rebuilt and the same pixi version is required", func() {
@@ -97,6 +103,7 @@ func pixiTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 
 			secondImage, logs, err = pack.WithNoColor().Build.
 				WithPullPolicy("never").
+				WithSBOMOutputDir(sbomDir).
 				WithBuildpacks(
 					settings.Buildpacks.PythonInstallers.Online,
 					settings.Buildpacks.BuildPlan.Online,
@@ -113,12 +120,15 @@ func pixiTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 				"      <unknown> -> \"\"",
 			))
 			Expect(logs).To(ContainLines(
-				MatchRegexp(`    Selected pixi version \(using <unknown>\): \d+\.\d+\.\d+`),
+				MatchRegexp(`    Selected pixi version \(using latest available\): \d+\.\d+\.\d+`),
 			))
 			Expect(logs).To(ContainLines(
 				fmt.Sprintf("  Reusing cached layer /layers/%s/pixi", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_")),
 			))
 
+			// check that the SBOM of the reused layer is still written
+			integration_helpers.ExpectLayerSBOM(t, sbomDir, buildpackInfo.Buildpack.ID, "pixi")
+
 			secondContainer, err = docker.Container.Run.
 				WithCommand("pixi --version").
 				Execute(secondImage.ID)
@@ -16,6 +16,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	. "github.com/paketo-buildpacks/occam/matchers"
+
+	integration_helpers "github.com/paketo-buildpacks/python-package-managers-install/integration"
 )
 
 func poetryTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
@@ -29,7 +31,8 @@ func poetryTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 		imageIDs     map[string]struct{}
 		containerIDs map[string]struct{}
 
-		source string
+		source  string
+		sbomDir string
 	)
 
 	it.Before(func() {
@@ -42,6 +45,10 @@ func poetryTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 		var err error
 		source, err = occam.Source(filepath.Join("testdata", "poetry", "poetry_app"))
 		Expect(err).NotTo(HaveOccurred())
+
+		sbomDir, err = os.MkdirTemp("", "sbom")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chmod(sbomDir, os.ModePerm)).To(Succeed())
 	})
 
 	it.After(func() {
@@ -54,6 +61,7 @@ func poetryTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 		}
 
 		Expect(os.RemoveAll(source)).To(Succeed())
+		Expect(os.RemoveAll(sbomDir)).To(Succeed())
 	})
 
 	context("when an app is rebuilt and does not change", func() {
@@ -100,6 +108,7 @@ func poetryTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 
 			secondImage, logs, err := pack.WithNoColor().Build.
 				WithPullPolicy("never").
+				WithSBOMOutputDir(sbomDir).
 				WithBuildpacks(
 					settings.Buildpacks.CPython.Online,
 					settings.Buildpacks.PythonInstallers.Online,
@@ -114,6 +123,9 @@ func poetryTestLayerReuse(t *testing.T, context spec.G, it spec.S) {
 				fmt.Sprintf("  Reusing cached layer /layers/%s/poetry", strings.ReplaceAll(buildpackInfo.Buildpack.ID, "/", "_")),
 			))
 
+			// check that the SBOM of the reused layer is still written
+			integration_helpers.ExpectLayerSBOM(t, sbomDir, buildpackInfo.Buildpack.ID, "poetry")
+
 			secondContainer, err := docker.Container.Run.
 				WithCommand("poetry --version").
 				Execute(secondImage.ID)
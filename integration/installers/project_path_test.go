@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package integration_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/occam"
+	"github.com/sclevine/spec"
+
+	. "github.com/onsi/gomega"
+	. "github.com/paketo-buildpacks/occam/matchers"
+)
+
+func projectPathTest(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		pack   occam.Pack
+		docker occam.Docker
+
+		image  occam.Image
+		name   string
+		source string
+	)
+
+	it.Before(func() {
+		pack = occam.NewPack().WithVerbose()
+		docker = occam.NewDocker()
+
+		var err error
+		name, err = occam.RandomName()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	it.After(func() {
+		Expect(docker.Image.Remove.Execute(image.ID)).To(Succeed())
+		Expect(docker.Volume.Remove.Execute(occam.CacheVolumeNames(name))).To(Succeed())
+		Expect(os.RemoveAll(source)).To(Succeed())
+	})
+
+	for _, app := range []struct {
+		installer string
+		fixture   string
+	}{
+		{"Pip", filepath.Join("pip", "pip_monorepo_app")},
+		{"Poetry", filepath.Join("poetry", "poetry_monorepo_app")},
+	} {
+		context(fmt.Sprintf("when the %s project lives in a sub-directory", app.installer), func() {
+			it.Before(func() {
+				var err error
+				source, err = occam.Source(filepath.Join("testdata", app.fixture))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			it("detects the project and exports its path", func() {
+				var (
+					err  error
+					logs fmt.Stringer
+				)
+
+				image, logs, err = pack.WithNoColor().Build.
+					WithPullPolicy("never").
+					WithBuildpacks(
+						settings.Buildpacks.CPython.Online,
+						settings.Buildpacks.PythonInstallers.Online,
+						settings.Buildpacks.BuildPlan.Online,
+					).
+					WithEnv(map[string]string{
+						"BP_PYTHON_PROJECT_PATH": "services/api",
+					}).
+					Execute(name, source)
+				Expect(err).ToNot(HaveOccurred(), logs.String)
+
+				Expect(logs).To(ContainLines(
+					MatchRegexp(fmt.Sprintf(`  Resolving %s version`, app.installer)),
+				))
+				Expect(logs).To(ContainLines(
+					"Exporting python project path",
+					"  Configuring build environment",
+					`    PYTHON_PROJECT_PATH -> "/workspace/services/api"`,
+				))
+			})
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pythoninstallers
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/fs"
+
+	miniconda "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/miniconda"
+	pip "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pip"
+	pipenv "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pipenv"
+	pixi "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pixi"
+	poetry "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry"
+	uv "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/uv"
+)
+
+// EnvPackageManagers selects the package managers this buildpack may
+// provide. It is a comma separated list of package manager names. Names
+// prefixed with "!" are excluded, the others form an allow list.
+const EnvPackageManagers = "BP_PYTHON_PACKAGE_MANAGERS"
+
+// PackageManagers lists the package managers in the order their build plans
+// are offered during detection.
+var PackageManagers = []string{
+	pip.Pip,
+	miniconda.Conda,
+	pipenv.Pipenv,
+	poetry.PoetryDependency,
+	uv.Uv,
+	pixi.Pixi,
+}
+
+// Lockfiles maps the package managers to the lock file they write.
+var Lockfiles = map[string]string{
+	pipenv.Pipenv:           pipenv.LockfileName,
+	poetry.PoetryDependency: poetry.LockfileName,
+	uv.Uv:                   uv.LockfileName,
+	pixi.Pixi:               pixi.LockfileName,
+}
+
+var packageManagerAliases = map[string]string{
+	"miniconda": miniconda.Conda,
+}
+
+// PackageManagerSelection is the parsed content of BP_PYTHON_PACKAGE_MANAGERS.
+type PackageManagerSelection struct {
+	Allowed []string
+	Denied  []string
+}
+
+// ParsePackageManagerSelection parses the value of BP_PYTHON_PACKAGE_MANAGERS.
+// An empty value enables every package manager.
+func ParsePackageManagerSelection(value string) (PackageManagerSelection, error) {
+	var selection PackageManagerSelection
+
+	for _, field := range strings.Split(value, ",") {
+		name := strings.ToLower(strings.TrimSpace(field))
+		if name == "" {
+			continue
+		}
+
+		denied := strings.HasPrefix(name, "!")
+		name = strings.TrimSpace(strings.TrimPrefix(name, "!"))
+		if alias, ok := packageManagerAliases[name]; ok {
+			name = alias
+		}
+
+		if !slices.Contains(PackageManagers, name) {
+			return PackageManagerSelection{}, fmt.Errorf("unknown package manager %q in %s, valid values are: %s", name, EnvPackageManagers, strings.Join(PackageManagers, ", "))
+		}
+
+		if denied {
+			selection.Denied = append(selection.Denied, name)
+		} else {
+			selection.Allowed = append(selection.Allowed, name)
+		}
+	}
+
+	for _, name := range selection.Allowed {
+		if slices.Contains(selection.Denied, name) {
+			return PackageManagerSelection{}, fmt.Errorf("package manager %q is both allowed and excluded in %s", name, EnvPackageManagers)
+		}
+	}
+
+	return selection, nil
+}
+
+// Enabled returns whether the named package manager may be provided.
+func (s PackageManagerSelection) Enabled(name string) bool {
+	if slices.Contains(s.Denied, name) {
+		return false
+	}
+
+	return len(s.Allowed) == 0 || slices.Contains(s.Allowed, name)
+}
+
+// FindLockfiles returns, in plan order, the enabled package managers whose
+// lock file is present in workingDir. More than one entry means the
+// application carries lock files of competing package managers.
+func FindLockfiles(workingDir string, selection PackageManagerSelection) ([]string, error) {
+	var found []string
+
+	for _, name := range PackageManagers {
+		lockfile, ok := Lockfiles[name]
+		if !ok || !selection.Enabled(name) {
+			continue
+		}
+
+		exists, err := fs.Exists(filepath.Join(workingDir, lockfile))
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			found = append(found, name)
+		}
+	}
+
+	return found, nil
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pythoninstallers_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	pythoninstallers "github.com/paketo-buildpacks/python-package-managers-install"
+
+	. "github.com/onsi/gomega"
+)
+
+func testPackageManagers(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+	)
+
+	context("ParsePackageManagerSelection", func() {
+		it("enables every package manager when empty", func() {
+			selection, err := pythoninstallers.ParsePackageManagerSelection("")
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, name := range pythoninstallers.PackageManagers {
+				Expect(selection.Enabled(name)).To(BeTrue())
+			}
+		})
+
+		it("parses an allow list", func() {
+			selection, err := pythoninstallers.ParsePackageManagerSelection("poetry, UV")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.Allowed).To(Equal([]string{"poetry", "uv"}))
+
+			Expect(selection.Enabled("poetry")).To(BeTrue())
+			Expect(selection.Enabled("uv")).To(BeTrue())
+			Expect(selection.Enabled("pip")).To(BeFalse())
+		})
+
+		it("parses a deny list", func() {
+			selection, err := pythoninstallers.ParsePackageManagerSelection("!pip,!miniconda")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(selection.Denied).To(Equal([]string{"pip", "conda"}))
+
+			Expect(selection.Enabled("pip")).To(BeFalse())
+			Expect(selection.Enabled("conda")).To(BeFalse())
+			Expect(selection.Enabled("poetry")).To(BeTrue())
+		})
+
+		context("failure cases", func() {
+			context("when a package manager is unknown", func() {
+				it("returns an error", func() {
+					_, err := pythoninstallers.ParsePackageManagerSelection("pip,hatch")
+					Expect(err).To(MatchError(ContainSubstring(`unknown package manager "hatch" in BP_PYTHON_PACKAGE_MANAGERS`)))
+				})
+			})
+
+			context("when a package manager is both allowed and excluded", func() {
+				it("returns an error", func() {
+					_, err := pythoninstallers.ParsePackageManagerSelection("uv,!uv")
+					Expect(err).To(MatchError(`package manager "uv" is both allowed and excluded in BP_PYTHON_PACKAGE_MANAGERS`))
+				})
+			})
+		})
+	})
+
+	context("FindLockfiles", func() {
+		var workingDir string
+
+		it.Before(func() {
+			workingDir = t.TempDir()
+
+			for _, lockfile := range []string{"uv.lock", "poetry.lock", "Pipfile.lock"} {
+				Expect(os.WriteFile(filepath.Join(workingDir, lockfile), []byte{}, os.ModePerm)).To(Succeed())
+			}
+		})
+
+		it("returns the package managers owning a lock file in plan order", func() {
+			found, err := pythoninstallers.FindLockfiles(workingDir, pythoninstallers.PackageManagerSelection{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal([]string{"pipenv", "poetry", "uv"}))
+		})
+
+		it("ignores disabled package managers", func() {
+			found, err := pythoninstallers.FindLockfiles(workingDir, pythoninstallers.PackageManagerSelection{Denied: []string{"poetry"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal([]string{"pipenv", "uv"}))
+		})
+	})
+}
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package build_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitBuild(t *testing.T) {
+	suite := spec.New("build", spec.Report(report.Terminal{}))
+	suite("LayerMetadata", testLayerMetadata)
+	suite.Run(t)
+}
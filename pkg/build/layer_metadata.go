@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package build
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/cargo"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+)
+
+// LayerMetadataSchemaVersion is the version of the layout of the metadata
+// stored in the layers TOML. It must be bumped whenever that layout changes
+// so that layers written by older buildpacks get rebuilt.
+const LayerMetadataSchemaVersion = 1
+
+// Keys of the layer metadata as stored in the layers TOML.
+const (
+	SchemaVersionKey      = "schema-version"
+	BuildpackVersionKey   = "buildpack-version"
+	DependencyChecksumKey = "dependency-checksum"
+	DependencyVersionKey  = "dependency-version"
+	DependencyArchKey     = "dependency-arch"
+	InstallOptionsKey     = "install-options"
+)
+
+// LayerMetadata describes what was installed in a layer and how. Two layers
+// with identical metadata are interchangeable which makes it the key used to
+// decide whether a cached layer can be reused.
+type LayerMetadata struct {
+	SchemaVersion      int
+	BuildpackVersion   string
+	DependencyChecksum string
+	DependencyVersion  string
+	DependencyArch     string
+
+	// InstallOptions holds the installer specific settings that affect the
+	// content of the layer (e.g. the install mode or the python interpreter).
+	InstallOptions map[string]string
+}
+
+// NewLayerMetadata creates the LayerMetadata of a layer containing the given
+// dependency installed by the given buildpack version.
+func NewLayerMetadata(dependency postal.Dependency, buildpackVersion string, installOptions map[string]string) LayerMetadata {
+	checksum := dependency.Checksum
+	if checksum == "" {
+		//nolint:staticcheck // SHA256 is only a fallback in case Checksum is not present
+		checksum = dependency.SHA256
+	}
+
+	arch := dependency.Arch
+	if arch == "" {
+		arch = runtime.GOARCH
+	}
+
+	if installOptions == nil {
+		installOptions = map[string]string{}
+	}
+
+	return LayerMetadata{
+		SchemaVersion:      LayerMetadataSchemaVersion,
+		BuildpackVersion:   buildpackVersion,
+		DependencyChecksum: checksum,
+		DependencyVersion:  dependency.Version,
+		DependencyArch:     arch,
+		InstallOptions:     installOptions,
+	}
+}
+
+// ParseLayerMetadata reads the LayerMetadata from the metadata of a layer.
+// Layers written before the schema was versioned are returned with a
+// SchemaVersion of 0.
+func ParseLayerMetadata(metadata map[string]interface{}) LayerMetadata {
+	var layerMetadata LayerMetadata
+
+	// TOML integers are decoded as int64 while freshly built layers hold int.
+	switch version := metadata[SchemaVersionKey].(type) {
+	case int:
+		layerMetadata.SchemaVersion = version
+	case int64:
+		layerMetadata.SchemaVersion = int(version)
+	}
+
+	layerMetadata.BuildpackVersion, _ = metadata[BuildpackVersionKey].(string)
+	layerMetadata.DependencyChecksum, _ = metadata[DependencyChecksumKey].(string)
+	layerMetadata.DependencyVersion, _ = metadata[DependencyVersionKey].(string)
+	layerMetadata.DependencyArch, _ = metadata[DependencyArchKey].(string)
+
+	layerMetadata.InstallOptions = map[string]string{}
+	switch options := metadata[InstallOptionsKey].(type) {
+	case map[string]string:
+		for key, value := range options {
+			layerMetadata.InstallOptions[key] = value
+		}
+	case map[string]interface{}:
+		for key, value := range options {
+			layerMetadata.InstallOptions[key] = fmt.Sprint(value)
+		}
+	}
+
+	return layerMetadata
+}
+
+// ToMap returns the representation of the LayerMetadata suitable for
+// packit.Layer.Metadata.
+func (m LayerMetadata) ToMap() map[string]interface{} {
+	installOptions := map[string]interface{}{}
+	for key, value := range m.InstallOptions {
+		installOptions[key] = value
+	}
+
+	return map[string]interface{}{
+		SchemaVersionKey:      m.SchemaVersion,
+		BuildpackVersionKey:   m.BuildpackVersion,
+		DependencyChecksumKey: m.DependencyChecksum,
+		DependencyVersionKey:  m.DependencyVersion,
+		DependencyArchKey:     m.DependencyArch,
+		InstallOptionsKey:     installOptions,
+	}
+}
+
+// Matches compares the LayerMetadata with the metadata of a cached layer. It
+// returns whether the cached layer can be reused and, when it cannot, the
+// reason why. The reason is empty when there is no cached layer at all.
+func (m LayerMetadata) Matches(cachedMetadata map[string]interface{}) (bool, string) {
+	if len(cachedMetadata) == 0 {
+		return false, ""
+	}
+
+	reason := m.invalidationReason(ParseLayerMetadata(cachedMetadata))
+	return reason == "", reason
+}
+
+func (m LayerMetadata) invalidationReason(cached LayerMetadata) string {
+	if cached.SchemaVersion != m.SchemaVersion {
+		if cached.SchemaVersion == 0 {
+			return fmt.Sprintf("layer metadata uses the legacy schema instead of version %d", m.SchemaVersion)
+		}
+		return fmt.Sprintf("layer metadata schema changed from version %d to %d", cached.SchemaVersion, m.SchemaVersion)
+	}
+
+	var reasons []string
+	changed := func(name, previous, current string) {
+		if previous != current {
+			reasons = append(reasons, fmt.Sprintf("%s changed from %q to %q", name, previous, current))
+		}
+	}
+
+	changed("buildpack version", cached.BuildpackVersion, m.BuildpackVersion)
+
+	if cached.DependencyChecksum == "" || !cargo.Checksum(cached.DependencyChecksum).MatchString(m.DependencyChecksum) {
+		reasons = append(reasons, fmt.Sprintf("dependency checksum changed from %q to %q", cached.DependencyChecksum, m.DependencyChecksum))
+	}
+
+	changed("dependency version", cached.DependencyVersion, m.DependencyVersion)
+	changed("dependency arch", cached.DependencyArch, m.DependencyArch)
+
+	var options []string
+	for key := range m.InstallOptions {
+		options = append(options, key)
+	}
+	for key := range cached.InstallOptions {
+		if _, ok := m.InstallOptions[key]; !ok {
+			options = append(options, key)
+		}
+	}
+	sort.Strings(options)
+
+	for _, key := range options {
+		changed(key, cached.InstallOptions[key], m.InstallOptions[key])
+	}
+
+	return strings.Join(reasons, ", ")
+}
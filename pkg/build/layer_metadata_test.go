@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package build_test
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
+
+	. "github.com/onsi/gomega"
+)
+
+func testLayerMetadata(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		dependency    postal.Dependency
+		layerMetadata build.LayerMetadata
+	)
+
+	it.Before(func() {
+		dependency = postal.Dependency{
+			ID:       "some-dependency",
+			Checksum: "sha256:some-sha",
+			Version:  "1.2.3",
+			Arch:     "amd64",
+		}
+
+		layerMetadata = build.NewLayerMetadata(dependency, "some-buildpack-version", map[string]string{
+			"some-option": "some-value",
+		})
+	})
+
+	context("NewLayerMetadata", func() {
+		it("records the dependency and the buildpack version", func() {
+			Expect(layerMetadata).To(Equal(build.LayerMetadata{
+				SchemaVersion:      build.LayerMetadataSchemaVersion,
+				BuildpackVersion:   "some-buildpack-version",
+				DependencyChecksum: "sha256:some-sha",
+				DependencyVersion:  "1.2.3",
+				DependencyArch:     "amd64",
+				InstallOptions:     map[string]string{"some-option": "some-value"},
+			}))
+		})
+
+		context("when the dependency has no checksum nor arch", func() {
+			it.Before(func() {
+				dependency.Checksum = ""
+				dependency.SHA256 = "some-legacy-sha" //nolint:staticcheck
+				dependency.Arch = ""
+			})
+
+			it("falls back to the SHA256 and the build architecture", func() {
+				layerMetadata = build.NewLayerMetadata(dependency, "some-buildpack-version", nil)
+				Expect(layerMetadata.DependencyChecksum).To(Equal("some-legacy-sha"))
+				Expect(layerMetadata.DependencyArch).To(Equal(runtime.GOARCH))
+				Expect(layerMetadata.InstallOptions).To(BeEmpty())
+			})
+		})
+	})
+
+	context("ToMap and ParseLayerMetadata", func() {
+		it("round trips through the layer TOML", func() {
+			buffer := bytes.NewBuffer(nil)
+			Expect(toml.NewEncoder(buffer).Encode(layerMetadata.ToMap())).To(Succeed())
+
+			var metadata map[string]interface{}
+			_, err := toml.Decode(buffer.String(), &metadata)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(build.ParseLayerMetadata(metadata)).To(Equal(layerMetadata))
+		})
+
+		it("returns a schema version of 0 for legacy metadata", func() {
+			parsed := build.ParseLayerMetadata(map[string]interface{}{
+				"dependency-sha": "some-sha",
+			})
+			Expect(parsed.SchemaVersion).To(Equal(0))
+		})
+	})
+
+	context("Matches", func() {
+		var cached map[string]interface{}
+
+		it.Before(func() {
+			cached = layerMetadata.ToMap()
+		})
+
+		it("matches identical metadata", func() {
+			reusable, reason := layerMetadata.Matches(cached)
+			Expect(reusable).To(BeTrue())
+			Expect(reason).To(BeEmpty())
+		})
+
+		it("matches equivalent checksums", func() {
+			cached[build.DependencyChecksumKey] = "some-sha"
+
+			reusable, _ := layerMetadata.Matches(cached)
+			Expect(reusable).To(BeTrue())
+		})
+
+		it("does not give a reason when there is no cached layer", func() {
+			reusable, reason := layerMetadata.Matches(map[string]interface{}{})
+			Expect(reusable).To(BeFalse())
+			Expect(reason).To(BeEmpty())
+		})
+
+		it("invalidates layers using the legacy schema", func() {
+			reusable, reason := layerMetadata.Matches(map[string]interface{}{
+				"dependency_checksum": "sha256:some-sha",
+			})
+			Expect(reusable).To(BeFalse())
+			Expect(reason).To(Equal("layer metadata uses the legacy schema instead of version 1"))
+		})
+
+		it("invalidates layers using another schema version", func() {
+			cached[build.SchemaVersionKey] = int64(2)
+
+			reusable, reason := layerMetadata.Matches(cached)
+			Expect(reusable).To(BeFalse())
+			Expect(reason).To(Equal("layer metadata schema changed from version 2 to 1"))
+		})
+
+		it("lists every change", func() {
+			cached[build.BuildpackVersionKey] = "some-old-buildpack-version"
+			cached[build.DependencyChecksumKey] = "sha256:some-other-sha"
+			cached[build.DependencyVersionKey] = "1.2.2"
+			cached[build.DependencyArchKey] = "arm64"
+			cached[build.InstallOptionsKey] = map[string]interface{}{
+				"some-option":  "some-other-value",
+				"other-option": "other-value",
+			}
+
+			reusable, reason := layerMetadata.Matches(cached)
+			Expect(reusable).To(BeFalse())
+			Expect(reason).To(Equal(`buildpack version changed from "some-old-buildpack-version" to "some-buildpack-version", ` +
+				`dependency checksum changed from "sha256:some-other-sha" to "sha256:some-sha", ` +
+				`dependency version changed from "1.2.2" to "1.2.3", ` +
+				`dependency arch changed from "arm64" to "amd64", ` +
+				`other-option changed from "other-value" to "", ` +
+				`some-option changed from "some-other-value" to "some-value"`))
+		})
+	})
+}
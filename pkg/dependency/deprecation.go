@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dependency
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+const (
+	// EnvFailOnDeprecated turns the use of a dependency past its deprecation
+	// date into a build failure.
+	EnvFailOnDeprecated = "BP_FAIL_ON_DEPRECATED"
+
+	// DeprecationNotice is how long before its deprecation date the use of
+	// a dependency is warned about.
+	DeprecationNotice = 30 * 24 * time.Hour
+)
+
+// FailOnDeprecated returns whether BP_FAIL_ON_DEPRECATED requests the build
+// to fail when a deprecated dependency is selected.
+func FailOnDeprecated() (bool, error) {
+	value, ok := os.LookupEnv(EnvFailOnDeprecated)
+	if !ok || value == "" {
+		return false, nil
+	}
+
+	fail, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for %s: %w", value, EnvFailOnDeprecated, err)
+	}
+
+	return fail, nil
+}
+
+// CheckDeprecation warns when the deprecation date of dependency is near or
+// past. Past that date, it returns an error if BP_FAIL_ON_DEPRECATED is set.
+func CheckDeprecation(logger scribe.Emitter, dependency postal.Dependency, now time.Time) error {
+	deprecationDate := dependency.DeprecationDate
+	if deprecationDate.IsZero() || now.Before(deprecationDate.Add(-DeprecationNotice)) {
+		return nil
+	}
+
+	date := deprecationDate.Format(time.DateOnly)
+
+	if now.Before(deprecationDate) {
+		logger.Process("Warning: %s %s reaches its end of life on %s", dependency.Name, dependency.Version, date)
+		logger.Subprocess("Migrate your application to a supported version of %s before this date.", dependency.Name)
+		logger.Break()
+		return nil
+	}
+
+	fail, err := FailOnDeprecated()
+	if err != nil {
+		return err
+	}
+
+	if fail {
+		return fmt.Errorf("%s %s reached its end of life on %s and %s is set, migrate your application to a supported version of %s", dependency.Name, dependency.Version, date, EnvFailOnDeprecated, dependency.Name)
+	}
+
+	logger.Process("Warning: %s %s reached its end of life on %s", dependency.Name, dependency.Version, date)
+	logger.Subprocess("Migrate your application to a supported version of %s.", dependency.Name)
+	logger.Subprocess("Set %s=true to make such builds fail.", EnvFailOnDeprecated)
+	logger.Break()
+
+	return nil
+}
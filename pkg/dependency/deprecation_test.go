@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dependency_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+
+	. "github.com/onsi/gomega"
+)
+
+func testDeprecation(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		buffer     *bytes.Buffer
+		logger     scribe.Emitter
+		now        time.Time
+		deprecated postal.Dependency
+	)
+
+	it.Before(func() {
+		buffer = bytes.NewBuffer(nil)
+		logger = scribe.NewEmitter(buffer)
+		now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+		deprecated = postal.Dependency{
+			ID:      "poetry",
+			Name:    "poetry",
+			Version: "1.8.5",
+		}
+	})
+
+	it("does not warn without deprecation date", func() {
+		Expect(dependency.CheckDeprecation(logger, deprecated, now)).To(Succeed())
+		Expect(buffer.String()).To(BeEmpty())
+	})
+
+	it("does not warn long before the deprecation date", func() {
+		deprecated.DeprecationDate = now.AddDate(0, 6, 0)
+
+		Expect(dependency.CheckDeprecation(logger, deprecated, now)).To(Succeed())
+		Expect(buffer.String()).To(BeEmpty())
+	})
+
+	it("warns when the deprecation date is near", func() {
+		deprecated.DeprecationDate = now.AddDate(0, 0, 10)
+		t.Setenv("BP_FAIL_ON_DEPRECATED", "true")
+
+		Expect(dependency.CheckDeprecation(logger, deprecated, now)).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("Warning: poetry 1.8.5 reaches its end of life on 2026-06-11"))
+	})
+
+	it("warns when the deprecation date is past", func() {
+		deprecated.DeprecationDate = now.AddDate(0, -1, 0)
+
+		Expect(dependency.CheckDeprecation(logger, deprecated, now)).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("Warning: poetry 1.8.5 reached its end of life on 2026-05-01"))
+		Expect(buffer.String()).To(ContainSubstring("Set BP_FAIL_ON_DEPRECATED=true to make such builds fail."))
+	})
+
+	it("fails when the deprecation date is past and BP_FAIL_ON_DEPRECATED is set", func() {
+		deprecated.DeprecationDate = now.AddDate(0, -1, 0)
+		t.Setenv("BP_FAIL_ON_DEPRECATED", "true")
+
+		err := dependency.CheckDeprecation(logger, deprecated, now)
+		Expect(err).To(MatchError(ContainSubstring("poetry 1.8.5 reached its end of life on 2026-05-01 and BP_FAIL_ON_DEPRECATED is set")))
+	})
+
+	it("warns when BP_FAIL_ON_DEPRECATED is false", func() {
+		deprecated.DeprecationDate = now.AddDate(0, -1, 0)
+		t.Setenv("BP_FAIL_ON_DEPRECATED", "false")
+
+		Expect(dependency.CheckDeprecation(logger, deprecated, now)).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("Warning: poetry 1.8.5 reached its end of life"))
+	})
+
+	it("returns an error for an invalid BP_FAIL_ON_DEPRECATED", func() {
+		deprecated.DeprecationDate = now.AddDate(0, -1, 0)
+		t.Setenv("BP_FAIL_ON_DEPRECATED", "sometimes")
+
+		err := dependency.CheckDeprecation(logger, deprecated, now)
+		Expect(err).To(MatchError(ContainSubstring(`invalid value "sometimes" for BP_FAIL_ON_DEPRECATED`)))
+	})
+}
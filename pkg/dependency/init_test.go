@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dependency_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitDependency(t *testing.T) {
+	suite := spec.New("dependency", spec.Report(report.Terminal{}), spec.Sequential())
+	suite("Deprecation", testDeprecation)
+	suite("Python", testPython)
+	suite("Version", testVersion)
+	suite.Run(t)
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dependency
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var specifierPattern = regexp.MustCompile(`^(~=|===|==|!=|<=|>=|<|>)\s*(\S+)$`)
+
+// PythonConstraint converts a PEP 440 version specifier, as found in the
+// requires-python field of a python package, to a semver constraint.
+func PythonConstraint(requiresPython string) (*semver.Constraints, error) {
+	var clauses []string
+	for _, specifier := range strings.Split(requiresPython, ",") {
+		specifier = strings.TrimSpace(specifier)
+		if specifier == "" {
+			continue
+		}
+
+		matches := specifierPattern.FindStringSubmatch(specifier)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid requires-python specifier %q", specifier)
+		}
+
+		operator, version := matches[1], matches[2]
+		switch operator {
+		case "~=":
+			// ~=X.Y allows X.*, ~=X.Y.Z allows X.Y.*
+			operator = "^"
+			if strings.Count(version, ".") > 1 {
+				operator = "~"
+			}
+		case "==", "===":
+			operator = "="
+		}
+
+		clauses = append(clauses, operator+version)
+	}
+
+	if len(clauses) == 0 {
+		return semver.NewConstraint("*")
+	}
+
+	return semver.NewConstraint(strings.Join(clauses, ", "))
+}
+
+// PythonCompatible returns whether a package declaring requiresPython can be
+// installed with the python X.Y version. An empty requiresPython is
+// compatible with any version.
+func PythonCompatible(requiresPython, python string) (bool, error) {
+	if requiresPython == "" {
+		return true, nil
+	}
+
+	constraint, err := PythonConstraint(requiresPython)
+	if err != nil {
+		return false, err
+	}
+
+	version, err := semver.NewVersion(python)
+	if err != nil {
+		return false, fmt.Errorf("invalid python version %q: %w", python, err)
+	}
+
+	return constraint.Check(version), nil
+}
+
+// excludeVersions restricts constraint, a constraint as accepted by
+// postal.Service.Resolve, to not match versions.
+func excludeVersions(constraint string, versions []string) string {
+	if len(versions) == 0 {
+		return constraint
+	}
+
+	constraint = pessimistic(constraint)
+
+	var exclusions strings.Builder
+	for _, version := range versions {
+		fmt.Fprintf(&exclusions, ", !=%s", version)
+	}
+
+	groups := strings.Split(constraint, "||")
+	for i, group := range groups {
+		groups[i] = strings.TrimSpace(group) + exclusions.String()
+	}
+
+	return strings.Join(groups, " || ")
+}
+
+// pessimistic converts a ~> constraint the way postal.Service.Resolve does:
+// ~>X.Y.Z allows X.Y.*, ~>X.Y and ~>X allow X.*. postal.Service.Resolve only
+// understands ~> as the whole constraint, it has to be converted before being
+// combined with other constraints.
+func pessimistic(constraint string) string {
+	operand, ok := strings.CutPrefix(strings.TrimSpace(constraint), "~>")
+	if !ok {
+		return constraint
+	}
+
+	operand = strings.TrimSpace(operand)
+	if strings.Count(operand, ".") == 2 {
+		return "~" + operand
+	}
+	return "^" + operand
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dependency_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+
+	. "github.com/onsi/gomega"
+)
+
+func testPython(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("PythonCompatible", func() {
+		for _, tc := range []struct {
+			requiresPython, python string
+			compatible             bool
+		}{
+			{"", "3.8", true},
+			{">=3.8", "3.8", true},
+			{">=3.9", "3.8", false},
+			{">=3.9,<4.0", "3.12", true},
+			{">=3.9, <3.12", "3.12", false},
+			{"~=3.9", "3.13", true},
+			{"~=3.9", "4.0", false},
+			{"~=3.9.0", "3.10", false},
+			{"==3.12.*", "3.12", true},
+			{"==3.12.*", "3.11", false},
+			{">=3.7,!=3.9.*", "3.9", false},
+			{">=3.7,!=3.9.*", "3.10", true},
+		} {
+			it("checks python "+tc.python+" against "+tc.requiresPython, func() {
+				compatible, err := dependency.PythonCompatible(tc.requiresPython, tc.python)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(compatible).To(Equal(tc.compatible))
+			})
+		}
+
+		it("rejects invalid specifiers", func() {
+			_, err := dependency.PythonCompatible("python3", "3.12")
+			Expect(err).To(MatchError(ContainSubstring(`invalid requires-python specifier "python3"`)))
+		})
+
+		it("rejects invalid python versions", func() {
+			_, err := dependency.PythonCompatible(">=3.9", "cpython")
+			Expect(err).To(MatchError(ContainSubstring(`invalid python version "cpython"`)))
+		})
+	})
+}
@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dependency
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/chronos"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+const (
+	// DefaultVersion requests the version listed for the dependency in
+	// [metadata.default-versions] of buildpack.toml.
+	DefaultVersion = "default"
+
+	// LatestVersion requests the most recent version of the dependency.
+	LatestVersion = "latest"
+
+	// DefaultVersionsSource is logged as the version source when the
+	// version comes from [metadata.default-versions].
+	DefaultVersionsSource = "buildpack.toml default-versions"
+
+	// LatestVersionSource is logged as the version source when no version
+	// was requested and buildpack.toml has no default for the dependency.
+	LatestVersionSource = "latest available"
+)
+
+// ShortReleaseIDs lists the dependencies whose X.Y.0 releases are published
+// as X.Y. For them X.Y designates that exact release, for the others the most
+// recent X.Y.Z release.
+var ShortReleaseIDs = []string{"pip"}
+
+var (
+	majorPattern      = regexp.MustCompile(`^v?\d+$`)
+	majorMinorPattern = regexp.MustCompile(`^v?\d+\.\d+$`)
+)
+
+// Constraint converts the version requested for the dependency id, be it by
+// a BP_*_VERSION environment variable or a build plan requirement, to a
+// constraint postal.Service.Resolve understands:
+//   - "" and "default" select the default version of buildpack.toml and are
+//     returned as an empty string
+//   - "latest" selects the most recent version
+//   - "X" and "X.Y" select the most recent X.*.* and X.Y.* version
+//   - exact versions and semver ranges are used as is
+func Constraint(id, version string) (string, error) {
+	version = strings.TrimSpace(version)
+
+	switch {
+	case version == "" || strings.EqualFold(version, DefaultVersion):
+		return "", nil
+	case strings.EqualFold(version, LatestVersion):
+		return "*", nil
+	case majorPattern.MatchString(version):
+		return strings.TrimPrefix(version, "v") + ".*", nil
+	case majorMinorPattern.MatchString(version):
+		version = strings.TrimPrefix(version, "v")
+		if slices.Contains(ShortReleaseIDs, id) {
+			return version + ".0", nil
+		}
+		return version + ".*", nil
+	}
+
+	_, err := semver.NewConstraint(strings.ReplaceAll(version, "~>", "~"))
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q for %s, expected %q, %q, X, X.Y, X.Y.Z or a semver range: %w", version, id, DefaultVersion, LatestVersion, err)
+	}
+
+	return version, nil
+}
+
+// buildpackMetadata is the part of the metadata of buildpack.toml not
+// covered by postal.Service.
+type buildpackMetadata struct {
+	DefaultVersions map[string]string `toml:"default-versions"`
+	Dependencies    []struct {
+		ID             string `toml:"id"`
+		Version        string `toml:"version"`
+		RequiresPython string `toml:"requires-python"`
+	} `toml:"dependencies"`
+}
+
+func readBuildpackMetadata(path string) (buildpackMetadata, error) {
+	var buildpack struct {
+		Metadata buildpackMetadata `toml:"metadata"`
+	}
+
+	_, err := toml.DecodeFile(path, &buildpack)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return buildpackMetadata{}, nil
+		}
+		return buildpackMetadata{}, err
+	}
+
+	return buildpack.Metadata, nil
+}
+
+// DefaultVersions returns the [metadata.default-versions] table of the
+// buildpack.toml at path. A missing file has no default versions.
+func DefaultVersions(path string) (map[string]string, error) {
+	metadata, err := readBuildpackMetadata(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.DefaultVersions, nil
+}
+
+// RequiresPython returns the requires-python specifier of each version of
+// the dependency id listed in the buildpack.toml at path. Versions without
+// one are left out.
+func RequiresPython(path, id string) (map[string]string, error) {
+	metadata, err := readBuildpackMetadata(path)
+	if err != nil {
+		return nil, err
+	}
+
+	requiresPython := map[string]string{}
+	for _, dependency := range metadata.Dependencies {
+		if dependency.ID == id && dependency.RequiresPython != "" {
+			requiresPython[dependency.Version] = dependency.RequiresPython
+		}
+	}
+
+	return requiresPython, nil
+}
+
+// Resolve picks the version of the dependency id requested by entry among
+// the ones listed in the buildpack.toml of cnbPath and logs it along with
+// where the version comes from and its deprecation, see CheckDeprecation.
+func Resolve(manager DependencyManager, logger scribe.Emitter, clock chronos.Clock, cnbPath, id string, entry packit.BuildpackPlanEntry, stack string) (postal.Dependency, error) {
+	return ResolveForPython(manager, logger, clock, cnbPath, id, entry, stack, "")
+}
+
+// ResolveForPython works like Resolve but leaves out the versions whose
+// requires-python in buildpack.toml excludes the X.Y python version. An empty
+// python version considers all versions.
+func ResolveForPython(manager DependencyManager, logger scribe.Emitter, clock chronos.Clock, cnbPath, id string, entry packit.BuildpackPlanEntry, stack, python string) (postal.Dependency, error) {
+	buildpackToml := filepath.Join(cnbPath, "buildpack.toml")
+
+	requested, _ := entry.Metadata["version"].(string)
+	source, _ := entry.Metadata["version-source"].(string)
+
+	constraint, err := Constraint(id, requested)
+	if err != nil {
+		return postal.Dependency{}, err
+	}
+
+	metadata, err := readBuildpackMetadata(buildpackToml)
+	if err != nil {
+		return postal.Dependency{}, err
+	}
+
+	query := constraint
+	if constraint == "" {
+		source = LatestVersionSource
+		query = "*"
+		if defaultVersion, ok := metadata.DefaultVersions[id]; ok {
+			source = DefaultVersionsSource
+			query = defaultVersion
+		}
+	}
+
+	incompatible, err := incompatibleVersions(metadata, id, query, python)
+	if err != nil {
+		return postal.Dependency{}, err
+	}
+
+	if constraint != "" && constraint != requested {
+		logger.Subprocess("Requested version %q resolved as %q", requested, constraint)
+	}
+
+	if len(incompatible) > 0 {
+		logger.Subprocess("Skipping the %s versions not supporting python %s:", id, python)
+		for _, version := range incompatible {
+			logger.Action("%s requires python %s", version, requiresPythonOf(metadata, id, version))
+		}
+		constraint = excludeVersions(query, incompatible)
+	}
+
+	dependency, err := manager.Resolve(buildpackToml, id, constraint, stack)
+	if err != nil {
+		if len(incompatible) > 0 {
+			return postal.Dependency{}, fmt.Errorf("no version of %s matching %q supports python %s: %w", id, query, python, err)
+		}
+		return postal.Dependency{}, err
+	}
+
+	metadataCopy := map[string]interface{}{}
+	for key, value := range entry.Metadata {
+		metadataCopy[key] = value
+	}
+	if source != "" {
+		metadataCopy["version-source"] = source
+	}
+	entry.Metadata = metadataCopy
+
+	// The deprecation is reported by CheckDeprecation, more prominently
+	// than SelectedDependency does.
+	selected := dependency
+	selected.DeprecationDate = time.Time{}
+	logger.SelectedDependency(entry, selected, clock.Now())
+
+	err = CheckDeprecation(logger, dependency, clock.Now())
+	if err != nil {
+		return postal.Dependency{}, err
+	}
+
+	return dependency, nil
+}
+
+// incompatibleVersions lists the versions of the dependency id matching
+// constraint whose requires-python excludes python.
+func incompatibleVersions(metadata buildpackMetadata, id, constraint, python string) ([]string, error) {
+	if python == "" {
+		return nil, nil
+	}
+
+	matching, err := semver.NewConstraint(pessimistic(constraint))
+	if err != nil {
+		return nil, err
+	}
+
+	var incompatible []string
+	for _, dependency := range metadata.Dependencies {
+		if dependency.ID != id || slices.Contains(incompatible, dependency.Version) {
+			continue
+		}
+
+		version, err := semver.NewVersion(dependency.Version)
+		if err != nil || !matching.Check(version) {
+			continue
+		}
+
+		compatible, err := PythonCompatible(dependency.RequiresPython, python)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", id, dependency.Version, err)
+		}
+
+		if !compatible {
+			incompatible = append(incompatible, dependency.Version)
+		}
+	}
+
+	return incompatible, nil
+}
+
+func requiresPythonOf(metadata buildpackMetadata, id, version string) string {
+	for _, dependency := range metadata.Dependencies {
+		if dependency.ID == id && dependency.Version == version {
+			return dependency.RequiresPython
+		}
+	}
+	return ""
+}
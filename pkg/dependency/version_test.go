@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dependency_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/chronos"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency/fakes"
+
+	. "github.com/onsi/gomega"
+)
+
+func testVersion(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("Constraint", func() {
+		for _, tc := range []struct {
+			id, version, constraint string
+		}{
+			{"uv", "", ""},
+			{"uv", "default", ""},
+			{"uv", "latest", "*"},
+			{"uv", "LATEST", "*"},
+			{"uv", "0", "0.*"},
+			{"uv", "0.10", "0.10.*"},
+			{"uv", "v0.10", "0.10.*"},
+			{"pip", "26.0", "26.0.0"},
+			{"pip", "26", "26.*"},
+			{"poetry", "2.1.3", "2.1.3"},
+			{"poetry", ">=1.8, <2.0", ">=1.8, <2.0"},
+			{"poetry", "~> 1.8", "~> 1.8"},
+			{"pipenv", "2026.*", "2026.*"},
+		} {
+			it("converts "+tc.id+" "+tc.version, func() {
+				constraint, err := dependency.Constraint(tc.id, tc.version)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(constraint).To(Equal(tc.constraint))
+			})
+		}
+
+		it("rejects invalid versions", func() {
+			_, err := dependency.Constraint("uv", "newest")
+			Expect(err).To(MatchError(ContainSubstring(`invalid version "newest" for uv`)))
+		})
+	})
+
+	context("DefaultVersions", func() {
+		var cnbDir string
+
+		it.Before(func() {
+			cnbDir = t.TempDir()
+		})
+
+		it("returns the default versions", func() {
+			Expect(os.WriteFile(filepath.Join(cnbDir, "buildpack.toml"), []byte(`[metadata.default-versions]
+  uv = "0.10.*"
+`), 0644)).To(Succeed())
+
+			defaults, err := dependency.DefaultVersions(filepath.Join(cnbDir, "buildpack.toml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaults).To(Equal(map[string]string{"uv": "0.10.*"}))
+		})
+
+		it("returns nothing when the file does not exist", func() {
+			defaults, err := dependency.DefaultVersions(filepath.Join(cnbDir, "buildpack.toml"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaults).To(BeEmpty())
+		})
+
+		it("fails on a malformed file", func() {
+			Expect(os.WriteFile(filepath.Join(cnbDir, "buildpack.toml"), []byte("%%%"), 0644)).To(Succeed())
+
+			_, err := dependency.DefaultVersions(filepath.Join(cnbDir, "buildpack.toml"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	context("Resolve", func() {
+		var (
+			cnbDir  string
+			buffer  *bytes.Buffer
+			logger  scribe.Emitter
+			clock   chronos.Clock
+			manager *fakes.DependencyManager
+		)
+
+		it.Before(func() {
+			cnbDir = t.TempDir()
+			Expect(os.WriteFile(filepath.Join(cnbDir, "buildpack.toml"), []byte(`[metadata.default-versions]
+  uv = "0.10.*"
+`), 0644)).To(Succeed())
+
+			buffer = bytes.NewBuffer(nil)
+			logger = scribe.NewEmitter(buffer)
+			clock = chronos.NewClock(func() time.Time { return time.Now() })
+
+			manager = &fakes.DependencyManager{}
+			manager.ResolveCall.Returns.Dependency = postal.Dependency{
+				ID:      "uv",
+				Name:    "uv",
+				Version: "0.10.11",
+			}
+		})
+
+		it("uses the default version of buildpack.toml", func() {
+			resolved, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", packit.BuildpackPlanEntry{Name: "uv"}, "some-stack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolved.Version).To(Equal("0.10.11"))
+
+			Expect(manager.ResolveCall.Receives.Path).To(Equal(filepath.Join(cnbDir, "buildpack.toml")))
+			Expect(manager.ResolveCall.Receives.Id).To(Equal("uv"))
+			Expect(manager.ResolveCall.Receives.Version).To(BeEmpty())
+			Expect(manager.ResolveCall.Receives.Stack).To(Equal("some-stack"))
+
+			Expect(buffer.String()).To(ContainSubstring("Selected uv version (using buildpack.toml default-versions): 0.10.11"))
+		})
+
+		it("uses the latest version without default", func() {
+			_, err := dependency.Resolve(manager, logger, clock, cnbDir, "pixi", packit.BuildpackPlanEntry{
+				Name:     "pixi",
+				Metadata: map[string]interface{}{"version": "default"},
+			}, "some-stack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manager.ResolveCall.Receives.Version).To(BeEmpty())
+			Expect(buffer.String()).To(ContainSubstring("(using latest available)"))
+		})
+
+		it("normalises the requested version and logs its source", func() {
+			entry := packit.BuildpackPlanEntry{
+				Name: "uv",
+				Metadata: map[string]interface{}{
+					"version":        "latest",
+					"version-source": "BP_UV_VERSION",
+				},
+			}
+
+			_, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", entry, "some-stack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manager.ResolveCall.Receives.Version).To(Equal("*"))
+			Expect(buffer.String()).To(ContainSubstring(`Requested version "latest" resolved as "*"`))
+			Expect(buffer.String()).To(ContainSubstring("Selected uv version (using BP_UV_VERSION): 0.10.11"))
+			Expect(entry.Metadata["version-source"]).To(Equal("BP_UV_VERSION"))
+		})
+
+		it("does not log exact versions twice", func() {
+			_, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", packit.BuildpackPlanEntry{
+				Name:     "uv",
+				Metadata: map[string]interface{}{"version": "0.10.11", "version-source": "BP_UV_VERSION"},
+			}, "some-stack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(manager.ResolveCall.Receives.Version).To(Equal("0.10.11"))
+			Expect(buffer.String()).NotTo(ContainSubstring("Requested version"))
+		})
+
+		it("reports the deprecation once", func() {
+			manager.ResolveCall.Returns.Dependency.DeprecationDate = time.Now().AddDate(0, -1, 0)
+
+			_, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", packit.BuildpackPlanEntry{Name: "uv"}, "some-stack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(ContainSubstring("Warning: uv 0.10.11 reached its end of life"))
+			Expect(buffer.String()).NotTo(ContainSubstring("is deprecated"))
+		})
+
+		context("ResolveForPython", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(cnbDir, "buildpack.toml"), []byte(`[metadata.default-versions]
+  poetry = "2.*"
+
+[[metadata.dependencies]]
+  id = "poetry"
+  version = "1.8.5"
+  requires-python = ">=3.8,<4.0"
+
+[[metadata.dependencies]]
+  id = "poetry"
+  version = "2.2.1"
+  requires-python = ">=3.9,<4.0"
+
+[[metadata.dependencies]]
+  id = "poetry"
+  version = "2.3.2"
+  requires-python = ">=3.10,<4.0"
+`), 0644)).To(Succeed())
+			})
+
+			it("excludes the versions not supporting python", func() {
+				_, err := dependency.ResolveForPython(manager, logger, clock, cnbDir, "poetry", packit.BuildpackPlanEntry{Name: "poetry"}, "some-stack", "3.9")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(manager.ResolveCall.Receives.Version).To(Equal("2.*, !=2.3.2"))
+				Expect(buffer.String()).To(ContainSubstring("Skipping the poetry versions not supporting python 3.9:"))
+				Expect(buffer.String()).To(ContainSubstring("2.3.2 requires python >=3.10,<4.0"))
+				Expect(buffer.String()).To(ContainSubstring("(using buildpack.toml default-versions)"))
+			})
+
+			it("only mentions the versions matching the request", func() {
+				_, err := dependency.ResolveForPython(manager, logger, clock, cnbDir, "poetry", packit.BuildpackPlanEntry{
+					Name:     "poetry",
+					Metadata: map[string]interface{}{"version": "~> 1.8"},
+				}, "some-stack", "3.8")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(manager.ResolveCall.Receives.Version).To(Equal("~> 1.8"))
+				Expect(buffer.String()).NotTo(ContainSubstring("Skipping"))
+			})
+
+			it("converts the pessimistic operator before excluding versions", func() {
+				_, err := dependency.ResolveForPython(manager, logger, clock, cnbDir, "poetry", packit.BuildpackPlanEntry{
+					Name:     "poetry",
+					Metadata: map[string]interface{}{"version": "~> 2.2"},
+				}, "some-stack", "3.9")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(manager.ResolveCall.Receives.Version).To(Equal("^2.2, !=2.3.2"))
+			})
+
+			it("excludes the versions from each alternative", func() {
+				_, err := dependency.ResolveForPython(manager, logger, clock, cnbDir, "poetry", packit.BuildpackPlanEntry{
+					Name:     "poetry",
+					Metadata: map[string]interface{}{"version": "1.8.* || >=2.2"},
+				}, "some-stack", "3.8")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(manager.ResolveCall.Receives.Version).To(Equal("1.8.*, !=2.2.1, !=2.3.2 || >=2.2, !=2.2.1, !=2.3.2"))
+			})
+
+			it("considers all versions without python version", func() {
+				_, err := dependency.ResolveForPython(manager, logger, clock, cnbDir, "poetry", packit.BuildpackPlanEntry{Name: "poetry"}, "some-stack", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(manager.ResolveCall.Receives.Version).To(BeEmpty())
+			})
+
+			it("explains why no version could be selected", func() {
+				manager.ResolveCall.Returns.Error = errors.New("no compatible versions")
+
+				_, err := dependency.ResolveForPython(manager, logger, clock, cnbDir, "poetry", packit.BuildpackPlanEntry{Name: "poetry"}, "some-stack", "3.8")
+				Expect(err).To(MatchError(ContainSubstring(`no version of poetry matching "2.*" supports python 3.8: no compatible versions`)))
+			})
+		})
+
+		context("failure cases", func() {
+			it("returns an error for an invalid version", func() {
+				_, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", packit.BuildpackPlanEntry{
+					Name:     "uv",
+					Metadata: map[string]interface{}{"version": "newest"},
+				}, "some-stack")
+				Expect(err).To(MatchError(ContainSubstring(`invalid version "newest"`)))
+				Expect(manager.ResolveCall.CallCount).To(Equal(0))
+			})
+
+			it("returns an error when buildpack.toml is malformed", func() {
+				Expect(os.WriteFile(filepath.Join(cnbDir, "buildpack.toml"), []byte("%%%"), 0644)).To(Succeed())
+
+				_, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", packit.BuildpackPlanEntry{Name: "uv"}, "some-stack")
+				Expect(err).To(HaveOccurred())
+			})
+
+			it("returns an error for a deprecated version when BP_FAIL_ON_DEPRECATED is set", func() {
+				manager.ResolveCall.Returns.Dependency.DeprecationDate = time.Now().AddDate(0, -1, 0)
+				t.Setenv("BP_FAIL_ON_DEPRECATED", "true")
+
+				_, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", packit.BuildpackPlanEntry{Name: "uv"}, "some-stack")
+				Expect(err).To(MatchError(ContainSubstring("uv 0.10.11 reached its end of life")))
+			})
+
+			it("returns the error of the dependency manager", func() {
+				manager.ResolveCall.Returns.Error = errors.New("failed to resolve")
+
+				_, err := dependency.Resolve(manager, logger, clock, cnbDir, "uv", packit.BuildpackPlanEntry{Name: "uv"}, "some-stack")
+				Expect(err).To(MatchError("failed to resolve"))
+			})
+		})
+	})
+}
@@ -0,0 +1,19 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executable_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitExecutable(t *testing.T) {
+	suite := spec.New("executable", spec.Report(report.Terminal{}))
+	suite("Output", testOutput)
+	suite.Run(t)
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executable
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+// MaxOutputLines is the number of trailing output lines of a process that are
+// kept in memory to be reported when the process fails.
+const MaxOutputLines = 100
+
+// maxPartialLineLength bounds the size of a line that has not been terminated
+// yet.
+const maxPartialLineLength = 4096
+
+// RingBuffer is an io.Writer that only keeps the last lines written to it so
+// that memory usage stays bounded for very verbose processes.
+type RingBuffer struct {
+	mutex   sync.Mutex
+	lines   []string
+	next    int
+	full    bool
+	partial []byte
+}
+
+// NewRingBuffer creates a RingBuffer keeping at most size lines.
+func NewRingBuffer(size int) *RingBuffer {
+	if size < 1 {
+		size = 1
+	}
+
+	return &RingBuffer{
+		lines: make([]string, size),
+	}
+}
+
+// Write stores the complete lines contained in p, keeping any trailing
+// incomplete line until it is terminated by a later write.
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data := p
+	for {
+		index := bytes.IndexByte(data, '\n')
+		if index < 0 {
+			break
+		}
+
+		r.push(string(r.partial) + string(data[:index]))
+		r.partial = r.partial[:0]
+		data = data[index+1:]
+	}
+
+	// Keep the pending line bounded as well in case a process never emits a
+	// line feed (e.g. progress bars using carriage returns).
+	r.partial = append(r.partial, data...)
+	if len(r.partial) > maxPartialLineLength {
+		r.partial = r.partial[len(r.partial)-maxPartialLineLength:]
+	}
+
+	return len(p), nil
+}
+
+func (r *RingBuffer) push(line string) {
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % len(r.lines)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// String returns the lines currently held by the buffer in the order they
+// were written.
+func (r *RingBuffer) String() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var lines []string
+	if r.full {
+		lines = append(lines, r.lines[r.next:]...)
+	}
+	lines = append(lines, r.lines[:r.next]...)
+
+	if len(r.partial) > 0 {
+		lines = append(lines, string(r.partial))
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// NewOutputWriter returns a writer suitable for the Stdout and Stderr of a
+// process execution along with the buffer holding its last lines. When the
+// logger is configured at debug level, the output is also streamed live with
+// indentation.
+func NewOutputWriter(logger scribe.Emitter) (io.Writer, *RingBuffer) {
+	buffer := NewRingBuffer(MaxOutputLines)
+
+	if logger.Debug.ActionWriter == nil {
+		return buffer, buffer
+	}
+
+	return io.MultiWriter(buffer, logger.Debug.ActionWriter), buffer
+}
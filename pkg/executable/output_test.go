@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package executable_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
+
+	. "github.com/onsi/gomega"
+)
+
+func testOutput(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+	)
+
+	context("RingBuffer", func() {
+		var ringBuffer *executable.RingBuffer
+
+		it.Before(func() {
+			ringBuffer = executable.NewRingBuffer(3)
+		})
+
+		it("is empty by default", func() {
+			Expect(ringBuffer.String()).To(BeEmpty())
+		})
+
+		it("keeps the lines written to it", func() {
+			_, err := fmt.Fprint(ringBuffer, "first\nsec")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = fmt.Fprint(ringBuffer, "ond\nthird")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ringBuffer.String()).To(Equal("first\nsecond\nthird\n"))
+		})
+
+		it("only keeps the last lines", func() {
+			for i := range 10 {
+				_, err := fmt.Fprintf(ringBuffer, "line %d\n", i)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(ringBuffer.String()).To(Equal("line 7\nline 8\nline 9\n"))
+		})
+
+		it("bounds lines without line feed", func() {
+			_, err := fmt.Fprint(ringBuffer, strings.Repeat("a", 10000))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(len(ringBuffer.String())).To(BeNumerically("<", 10000))
+		})
+	})
+
+	context("NewOutputWriter", func() {
+		var buffer *bytes.Buffer
+
+		it.Before(func() {
+			buffer = bytes.NewBuffer(nil)
+		})
+
+		it("does not stream the output by default", func() {
+			writer, ringBuffer := executable.NewOutputWriter(scribe.NewEmitter(buffer))
+
+			_, err := fmt.Fprintln(writer, "some output")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(buffer.String()).To(BeEmpty())
+			Expect(ringBuffer.String()).To(Equal("some output\n"))
+		})
+
+		context("when the log level is debug", func() {
+			it("streams the output with indentation", func() {
+				writer, ringBuffer := executable.NewOutputWriter(scribe.NewEmitter(buffer).WithLevel("DEBUG"))
+
+				_, err := fmt.Fprintln(writer, "some output")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(Equal("      some output\n"))
+				Expect(ringBuffer.String()).To(Equal("some output\n"))
+			})
+		})
+	})
+}
@@ -10,12 +10,11 @@ import (
 	"time"
 
 	"github.com/paketo-buildpacks/packit/v2"
-	"github.com/paketo-buildpacks/packit/v2/cargo"
 	"github.com/paketo-buildpacks/packit/v2/draft"
-	"github.com/paketo-buildpacks/packit/v2/sbom"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
 )
 
 //go:generate faux --interface Runner --output fakes/runner.go
@@ -67,14 +66,12 @@ func Build(
 		entry.Name = "miniconda3"
 		logger.Candidates(sortedEntries)
 
-		version, _ := entry.Metadata["version"].(string)
-
-		dependency, err := dependencyManager.Resolve(filepath.Join(context.CNBPath, "buildpack.toml"), entry.Name, version, context.Stack)
+		dependency, err := dependency.Resolve(dependencyManager, logger, clock, context.CNBPath, entry.Name, entry, context.Stack)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
-		logger.SelectedDependency(entry, dependency, clock.Now())
+		layerMetadata := build.NewLayerMetadata(dependency, context.BuildpackInfo.Version, nil)
 		legacySBOM := dependencyManager.GenerateBillOfMaterials(dependency)
 
 		condaLayer, err := context.Layers.Get("conda")
@@ -94,19 +91,18 @@ func Build(
 			launchMetadata = packit.LaunchMetadata{BOM: legacySBOM}
 		}
 
-		cachedChecksum, ok := condaLayer.Metadata[DepKey].(string)
-		dependencyChecksum := dependency.Checksum
-		if dependencyChecksum == "" {
-			//nolint:staticcheck // SHA256 is only a fallback in case Checksum is not present
-			dependencyChecksum = dependency.SHA256
-		}
-
-		if ok && cachedChecksum != "" && cargo.Checksum(cachedChecksum).MatchString(dependencyChecksum) {
+		reusable, reason := layerMetadata.Matches(condaLayer.Metadata)
+		if reusable {
 			logger.Process("Reusing cached layer %s", condaLayer.Path)
 			logger.Break()
 
 			condaLayer.Launch, condaLayer.Build, condaLayer.Cache = launch, build, build
 
+			condaLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, condaLayer.Path, context.BuildpackInfo.SBOMFormats)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
 			return packit.BuildResult{
 				Layers: []packit.Layer{condaLayer},
 				Build:  buildMetadata,
@@ -114,6 +110,10 @@ func Build(
 			}, nil
 		}
 
+		if reason != "" {
+			logger.Process("Rebuilding cached layer %s: %s", condaLayer.Path, reason)
+		}
+
 		condaLayer, err = condaLayer.Reset()
 		if err != nil {
 			return packit.BuildResult{}, err
@@ -154,21 +154,7 @@ func Build(
 		logger.Action("Completed in %s", duration.Round(time.Millisecond))
 		logger.Break()
 
-		logger.GeneratingSBOM(condaLayer.Path)
-		var sbomContent sbom.SBOM
-		duration, err = clock.Measure(func() error {
-			sbomContent, err = sbomGenerator.GenerateFromDependency(dependency, condaLayer.Path)
-			return err
-		})
-		if err != nil {
-			return packit.BuildResult{}, err
-		}
-
-		logger.Action("Completed in %s", duration.Round(time.Millisecond))
-		logger.Break()
-
-		logger.FormattingSBOM(context.BuildpackInfo.SBOMFormats...)
-		condaLayer.SBOM, err = sbomContent.InFormats(context.BuildpackInfo.SBOMFormats...)
+		condaLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, condaLayer.Path, context.BuildpackInfo.SBOMFormats)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
@@ -177,9 +163,7 @@ func Build(
 
 		logger.EnvironmentVariables(condaLayer)
 
-		condaLayer.Metadata = map[string]interface{}{
-			DepKey: dependencyChecksum,
-		}
+		condaLayer.Metadata = layerMetadata.ToMap()
 
 		return packit.BuildResult{
 			Layers: []packit.Layer{condaLayer},
@@ -8,11 +8,6 @@ const (
 	// Conda is the name of the layer into which conda dependency is installed.
 	Conda = "conda"
 
-	// This is the key name that we use to store the sha of the script we
-	// download in the layer metadata, which is used to determine if the conda
-	// layer can be resued on during a rebuild.
-	DepKey = "dependency-sha"
-
 	// DepName is the name of the metadata.dependencies id
 	DepId = "miniconda3"
 
@@ -5,10 +5,10 @@
 package miniconda
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
 )
@@ -16,19 +16,21 @@ import (
 // ScriptRunner implements the Runner interface
 type ScriptRunner struct {
 	executable executable.Executable
+	logger     scribe.Emitter
 }
 
 // NewScriptRunner creates an instance of the ScriptRunner given an Executable that runs `bash`.
-func NewScriptRunner(executable executable.Executable) ScriptRunner {
+func NewScriptRunner(executable executable.Executable, logger scribe.Emitter) ScriptRunner {
 	return ScriptRunner{
 		executable: executable,
+		logger:     logger,
 	}
 }
 
 // Run invokes the miniconda script located in the given runPath, which
 // installs conda into the a layer path designated by condaLayerPath.
 func (s ScriptRunner) Run(runPath, condaLayerPath string) error {
-	buffer := bytes.NewBuffer(nil)
+	output, buffer := executable.NewOutputWriter(s.logger)
 	err := s.executable.Execute(pexec.Execution{
 		Args: []string{
 			runPath,
@@ -37,8 +39,8 @@ func (s ScriptRunner) Run(runPath, condaLayerPath string) error {
 			"-u",
 			"-p", condaLayerPath,
 		},
-		Stdout: buffer,
-		Stderr: buffer,
+		Stdout: output,
+		Stderr: output,
 	})
 	if err != nil {
 		return fmt.Errorf("failed while running miniconda install script:\n%s\nerror: %w", buffer.String(), err)
@@ -5,11 +5,15 @@
 package miniconda_test
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
@@ -27,6 +31,7 @@ func testScriptRunner(t *testing.T, context spec.G, it spec.S) {
 		scriptPath string
 
 		executable *fakes.Executable
+		buffer     *bytes.Buffer
 
 		scriptRunner miniconda.ScriptRunner
 	)
@@ -44,8 +49,9 @@ func testScriptRunner(t *testing.T, context spec.G, it spec.S) {
 		Expect(err).NotTo(HaveOccurred())
 
 		executable = &fakes.Executable{}
+		buffer = bytes.NewBuffer(nil)
 
-		scriptRunner = miniconda.NewScriptRunner(executable)
+		scriptRunner = miniconda.NewScriptRunner(executable, scribe.NewEmitter(buffer))
 	})
 
 	it.After(func() {
@@ -67,6 +73,27 @@ func testScriptRunner(t *testing.T, context spec.G, it spec.S) {
 			}))
 		})
 
+		context("when the log level is debug", func() {
+			it.Before(func() {
+				scriptRunner = miniconda.NewScriptRunner(executable, scribe.NewEmitter(buffer).WithLevel("DEBUG"))
+				executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+					_, err := fmt.Fprintln(execution.Stdout, "stdout output")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = fmt.Fprintln(execution.Stderr, "stderr output")
+					Expect(err).NotTo(HaveOccurred())
+					return nil
+				}
+			})
+
+			it("streams the process output", func() {
+				err := scriptRunner.Run(scriptPath, layersDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring("      stdout output\n"))
+				Expect(buffer.String()).To(ContainSubstring("      stderr output\n"))
+			})
+		})
+
 		context("failure cases", func() {
 			context("when the script fails", func() {
 				it.Before(func() {
@@ -6,21 +6,21 @@ package pip
 
 import (
 	"fmt"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/paketo-buildpacks/packit/v2"
-	"github.com/paketo-buildpacks/packit/v2/cargo"
 	"github.com/paketo-buildpacks/packit/v2/draft"
-	"github.com/paketo-buildpacks/packit/v2/sbom"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/interpreter"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
 )
 
 //go:generate faux --interface InstallProcess --output fakes/install_process.go
 //go:generate faux --interface SitePackageProcess --output fakes/site_package_process.go
+//go:generate faux --interface InterpreterProcess --output fakes/interpreter_process.go
 
 // InstallProcess defines the interface for installing the pip dependency into a layer.
 type InstallProcess interface {
@@ -32,12 +32,19 @@ type SitePackageProcess interface {
 	Execute(targetLayerPath string) (string, error)
 }
 
+// InterpreterProcess defines the interface for identifying the python
+// interpreter the layer is built with.
+type InterpreterProcess interface {
+	Execute() (string, error)
+}
+
 // PipBuildParameters encapsulates the pip specific parameters for the
 // Build function
 type PipBuildParameters struct {
 	DependencyManager  dependency.DependencyManager
 	InstallProcess     InstallProcess
 	SitePackageProcess SitePackageProcess
+	InterpreterProcess InterpreterProcess
 }
 
 // Build will return a packit.BuildFunc that will be invoked during the build
@@ -45,7 +52,7 @@ type PipBuildParameters struct {
 //
 // Build will find the right pip dependency to install, install it in a
 // layer, and generate Bill-of-Materials. It also makes use of the checksum of
-// the dependency to reuse the layer when possible.
+// the dependency and the python interpreter to reuse the layer when possible.
 func Build(
 	buildParameters PipBuildParameters,
 	parameters build.CommonBuildParameters,
@@ -53,6 +60,7 @@ func Build(
 	return func(context packit.BuildContext) (packit.BuildResult, error) {
 		installProcess := buildParameters.InstallProcess
 		siteProcess := buildParameters.SitePackageProcess
+		interpreterProcess := buildParameters.InterpreterProcess
 		dependencies := buildParameters.DependencyManager
 		sbomGenerator := parameters.SbomGenerator
 		clock := parameters.Clock
@@ -66,15 +74,19 @@ func Build(
 		entry, sortedEntries := planner.Resolve(Pip, context.Plan.Entries, Priorities)
 		logger.Candidates(sortedEntries)
 
-		version, _ := entry.Metadata["version"].(string)
+		pythonInterpreter, err := interpreterProcess.Execute()
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
 
-		dependency, err := dependencies.Resolve(filepath.Join(context.CNBPath, "buildpack.toml"), entry.Name, version, context.Stack)
+		dependency, err := dependency.ResolveForPython(dependencies, logger, clock, context.CNBPath, entry.Name, entry, context.Stack, interpreter.Version(pythonInterpreter))
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
-		dependency.Name = "Pip"
-		logger.SelectedDependency(entry, dependency, clock.Now())
+		layerMetadata := build.NewLayerMetadata(dependency, context.BuildpackInfo.Version, map[string]string{
+			InterpreterKey: pythonInterpreter,
+		})
 
 		legacySBOM := dependencies.GenerateBillOfMaterials(dependency)
 		launch, build := planner.MergeLayerTypes(Pip, context.Plan.Entries)
@@ -99,13 +111,18 @@ func Build(
 			return packit.BuildResult{}, err
 		}
 
-		cachedChecksum, ok := pipLayer.Metadata[DependencyChecksumKey].(string)
-		if ok && cargo.Checksum(cachedChecksum).Match(cargo.Checksum(dependency.Checksum)) {
+		reusable, reason := layerMetadata.Matches(pipLayer.Metadata)
+		if reusable {
 			logger.Process("Reusing cached layer %s", pipLayer.Path)
 			logger.Process("Reusing cached layer %s", pipSrcLayer.Path)
 			pipLayer.Launch, pipLayer.Build, pipLayer.Cache = launch, build, build
 			pipSrcLayer.Launch, pipSrcLayer.Build, pipSrcLayer.Cache = false, build, build
 
+			pipLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, pipLayer.Path, context.BuildpackInfo.SBOMFormats)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
 			return packit.BuildResult{
 				Layers: []packit.Layer{pipLayer, pipSrcLayer},
 				Build:  buildMetadata,
@@ -113,6 +130,10 @@ func Build(
 			}, nil
 		}
 
+		if reason != "" {
+			logger.Process("Rebuilding cached layer %s: %s", pipLayer.Path, reason)
+		}
+
 		pipLayer, err = pipLayer.Reset()
 		if err != nil {
 			return packit.BuildResult{}, err
@@ -145,21 +166,7 @@ func Build(
 		logger.Action("Completed in %s", duration.Round(time.Millisecond))
 		logger.Break()
 
-		logger.GeneratingSBOM(pipLayer.Path)
-		var sbomContent sbom.SBOM
-		duration, err = clock.Measure(func() error {
-			sbomContent, err = sbomGenerator.GenerateFromDependency(dependency, pipLayer.Path)
-			return err
-		})
-		if err != nil {
-			return packit.BuildResult{}, err
-		}
-
-		logger.Action("Completed in %s", duration.Round(time.Millisecond))
-		logger.Break()
-
-		logger.FormattingSBOM(context.BuildpackInfo.SBOMFormats...)
-		pipLayer.SBOM, err = sbomContent.InFormats(context.BuildpackInfo.SBOMFormats...)
+		pipLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, pipLayer.Path, context.BuildpackInfo.SBOMFormats)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
@@ -183,9 +190,7 @@ func Build(
 		logger.EnvironmentVariables(pipSrcLayer)
 		logger.EnvironmentVariables(pipLayer)
 
-		pipLayer.Metadata = map[string]interface{}{
-			DependencyChecksumKey: dependency.Checksum,
-		}
+		pipLayer.Metadata = layerMetadata.ToMap()
 
 		return packit.BuildResult{
 			Layers: []packit.Layer{pipLayer, pipSrcLayer},
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
@@ -25,6 +26,7 @@ import (
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	dependencyfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency/fakes"
 	sbomfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/sbomtest"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pip"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pip/fakes"
@@ -42,6 +44,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		dependencyManager  *dependencyfakes.DependencyManager
 		installProcess     *fakes.InstallProcess
 		sitePackageProcess *fakes.SitePackageProcess
+		interpreterProcess *fakes.InterpreterProcess
 		sbomGenerator      *sbomfakes.SBOMGenerator
 
 		logger scribe.Emitter
@@ -97,6 +100,9 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		sitePackageProcess = &fakes.SitePackageProcess{}
 		sitePackageProcess.ExecuteCall.Returns.String = filepath.Join(layersDir, "pip", "lib", "python1.23", "site-packages")
 
+		interpreterProcess = &fakes.InterpreterProcess{}
+		interpreterProcess.ExecuteCall.Returns.String = "1.23-cpython-123-x86_64-linux-gnu"
+
 		// Syft SBOM
 		sbomGenerator = &sbomfakes.SBOMGenerator{}
 		sbomGenerator.GenerateFromDependencyCall.Returns.SBOM = sbom.SBOM{}
@@ -109,6 +115,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 				dependencyManager,
 				installProcess,
 				sitePackageProcess,
+				interpreterProcess,
 			},
 			build.CommonBuildParameters{
 				SbomGenerator: sbomGenerator,
@@ -161,8 +168,16 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(pipLayer.Launch).To(BeFalse())
 		Expect(pipLayer.Cache).To(BeFalse())
 
-		Expect(pipLayer.Metadata).To(HaveLen(1))
-		Expect(pipLayer.Metadata["dependency_checksum"]).To(Equal("some-sha"))
+		Expect(pipLayer.Metadata).To(Equal(map[string]interface{}{
+			"schema-version":      1,
+			"buildpack-version":   "some-version",
+			"dependency-checksum": "some-sha",
+			"dependency-version":  "21.0",
+			"dependency-arch":     runtime.GOARCH,
+			"install-options": map[string]interface{}{
+				"interpreter": "1.23-cpython-123-x86_64-linux-gnu",
+			},
+		}))
 
 		Expect(pipLayer.SharedEnv).To(HaveLen(2))
 		Expect(pipLayer.SharedEnv["PYTHONPATH.delim"]).To(Equal(":"))
@@ -285,9 +300,14 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 	context("when rebuilding a layer", func() {
 		it.Before(func() {
 			err := os.WriteFile(filepath.Join(layersDir, fmt.Sprintf("%s.toml", pip.Pip)), []byte(fmt.Sprintf(`[metadata]
-			%s = "some-sha"
-			built_at = "some-build-time"
-			`, pip.DependencyChecksumKey)), os.ModePerm)
+			schema-version = 1
+			buildpack-version = "some-version"
+			dependency-checksum = "some-sha"
+			dependency-version = "21.0"
+			dependency-arch = "%s"
+			[metadata.install-options]
+			interpreter = "1.23-cpython-123-x86_64-linux-gnu"
+			`, runtime.GOARCH)), os.ModePerm)
 			Expect(err).NotTo(HaveOccurred())
 
 			buildContext.Plan.Entries[0].Metadata = make(map[string]interface{})
@@ -319,9 +339,78 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			Expect(buffer.String()).ToNot(ContainSubstring("Executing build process"))
 			Expect(buffer.String()).To(ContainSubstring("Reusing cached layer"))
 
+			sbomtest.ExpectLayerSBOM(t, result.Layers[0], sbomGenerator, filepath.Join(layersDir, "pip"))
+
 			Expect(dependencyManager.DeliverCall.CallCount).To(Equal(0))
 			Expect(installProcess.ExecuteCall.CallCount).To(Equal(0))
 		})
+
+		context("when generating the SBOM of the cached layer returns an error", func() {
+			it.Before(func() {
+				sbomGenerator.GenerateFromDependencyCall.Returns.Error = errors.New("failed to generate SBOM")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to generate SBOM")))
+			})
+		})
+
+		context("when the python interpreter changed", func() {
+			it.Before(func() {
+				interpreterProcess.ExecuteCall.Returns.String = "1.24-cpython-124-x86_64-linux-gnu"
+			})
+
+			it("rebuilds the layer and explains why", func() {
+				result, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.Layers).To(HaveLen(2))
+				Expect(result.Layers[0].Metadata["install-options"]).To(Equal(map[string]interface{}{
+					"interpreter": "1.24-cpython-124-x86_64-linux-gnu",
+				}))
+
+				Expect(buffer.String()).To(ContainSubstring(fmt.Sprintf(
+					`Rebuilding cached layer %s: interpreter changed from "1.23-cpython-123-x86_64-linux-gnu" to "1.24-cpython-124-x86_64-linux-gnu"`,
+					filepath.Join(layersDir, "pip"),
+				)))
+				Expect(buffer.String()).NotTo(ContainSubstring("Reusing cached layer"))
+
+				Expect(dependencyManager.DeliverCall.CallCount).To(Equal(1))
+				Expect(installProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
+		})
+
+		context("when the buildpack version changed", func() {
+			it.Before(func() {
+				buildContext.BuildpackInfo.Version = "some-other-version"
+			})
+
+			it("rebuilds the layer and explains why", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring(`buildpack version changed from "some-version" to "some-other-version"`))
+				Expect(installProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
+		})
+
+		context("when the layer metadata uses the legacy schema", func() {
+			it.Before(func() {
+				err := os.WriteFile(filepath.Join(layersDir, fmt.Sprintf("%s.toml", pip.Pip)), []byte(`[metadata]
+				dependency_checksum = "some-sha"
+				`), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			it("rebuilds the layer", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring("layer metadata uses the legacy schema instead of version 1"))
+				Expect(installProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
+		})
 	})
 
 	context("failure cases", func() {
@@ -336,6 +425,17 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("when the python interpreter cannot be identified", func() {
+			it.Before(func() {
+				interpreterProcess.ExecuteCall.Returns.Error = errors.New("failed to identify python interpreter")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to identify python interpreter")))
+			})
+		})
+
 		context("when pip layer cannot be fetched", func() {
 			it.Before(func() {
 				Expect(os.Chmod(layersDir, 0000)).To(Succeed())
@@ -13,12 +13,29 @@ const (
 	// CPython is the name of the python runtime dependency provided by the CPython buildpack: https://github.com/paketo-buildpacks/cpython
 	CPython = "cpython"
 
-	// DependencyChecksumKey is the name of the key in the pip layer TOML whose value is pip dependency's SHA256.
-	DependencyChecksumKey = "dependency_checksum"
+	// InterpreterKey is the name of the install option in the pip layer
+	// metadata whose value identifies the python interpreter the layer was
+	// built with.
+	InterpreterKey = "interpreter"
 
 	EnvVersion = "BP_PIP_VERSION"
+
+	// EnvAlwaysDetect restores the former behaviour of the pip and pipenv
+	// detectors which passed whatever the content of the application.
+	EnvAlwaysDetect = "BP_PYTHON_INSTALLERS_ALWAYS_DETECT"
+
+	// PyProjectTomlFile is the name of the PEP 517 project file.
+	PyProjectTomlFile = "pyproject.toml"
 )
 
+// ProjectFilePatterns lists the glob patterns of the files that make pip
+// pass detection besides a PEP 517 pyproject.toml.
+var ProjectFilePatterns = []string{
+	"requirements*.txt",
+	"setup.py",
+	"setup.cfg",
+}
+
 // Priorities is a list of possible places where the buildpack could look for a
 // specific version of Pip to install, ordered from highest to lowest priority.
 var Priorities = []interface{}{EnvVersion}
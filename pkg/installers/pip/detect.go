@@ -5,12 +5,17 @@
 package pip
 
 import (
+	"fmt"
 	"os"
-	"regexp"
+	"path/filepath"
+	"strconv"
 
 	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pythonversion"
 )
 
 // Return a pip requirement
@@ -20,16 +25,6 @@ func GetVersionedRequirement() *packit.BuildPlanRequirement {
 		return nil
 	}
 
-	// Pip releases are of the form X.Y rather than X.Y.0, so in order
-	// to support selecting the exact version X.Y we have to up-convert
-	// X.Y to X.Y.0.
-	// Otherwise X.Y would match the latest patch release
-	// X.Y.Z if it is available.
-	var xDotYPattern = regexp.MustCompile(`^\d+\.\d+$`)
-	if xDotYPattern.MatchString(pipVersion) {
-		pipVersion = pipVersion + ".0"
-	}
-
 	return &packit.BuildPlanRequirement{
 		Name: Pip,
 		Metadata: build.BuildPlanMetadata{
@@ -53,22 +48,90 @@ func GetRequirement() packit.BuildPlanRequirement {
 	}
 }
 
+// AlwaysDetect returns whether BP_PYTHON_INSTALLERS_ALWAYS_DETECT requests
+// the detectors to pass regardless of the application files.
+func AlwaysDetect() (bool, error) {
+	value, ok := os.LookupEnv(EnvAlwaysDetect)
+	if !ok || value == "" {
+		return false, nil
+	}
+
+	alwaysDetect, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q for %s: %w", value, EnvAlwaysDetect, err)
+	}
+
+	return alwaysDetect, nil
+}
+
+// hasProjectFiles returns whether the project contains files pip knows how to
+// install from.
+func hasProjectFiles(proj project.Project) (bool, error) {
+	for _, pattern := range ProjectFilePatterns {
+		matches, err := filepath.Glob(filepath.Join(proj.Path, pattern))
+		if err != nil {
+			return false, err
+		}
+
+		if len(matches) > 0 {
+			return true, nil
+		}
+	}
+
+	pyProject, err := proj.PyProject()
+	if err != nil {
+		return false, err
+	}
+
+	return pyProject.HasBuildSystem(), nil
+}
+
 // Detect will return a packit.DetectFunc that will be invoked during the
 // detect phase of the buildpack lifecycle.
 //
-// Detection always passes, and will contribute a  Build Plan that provides pip,
-// and requires cpython OR python, python_packages, and requirements.
+// Detection passes when the application contains a requirements*.txt file, a
+// setup.py, a setup.cfg or a pyproject.toml with a [build-system] table. It
+// will contribute a Build Plan that provides pip and requires cpython.
+// Setting $BP_PYTHON_INSTALLERS_ALWAYS_DETECT to true makes detection always
+// pass.
+//
+// The python version pinned in .python-version, if any, is required from
+// cpython.
 //
 // If a version is provided via the $BP_PIP_VERSION environment variable, that
 // version of pip will be a requirement.
-func Detect() packit.DetectFunc {
-	return func(_ packit.DetectContext) (packit.DetectResult, error) {
+func Detect(loader project.Loader, logger scribe.Emitter) packit.DetectFunc {
+	return func(context packit.DetectContext) (packit.DetectResult, error) {
+		proj, err := loader.Load(context.WorkingDir)
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
+
+		alwaysDetect, err := AlwaysDetect()
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
+
+		if !alwaysDetect {
+			found, err := hasProjectFiles(proj)
+			if err != nil {
+				return packit.DetectResult{}, err
+			}
+
+			if !found {
+				return packit.DetectResult{}, packit.Fail.WithMessage("no requirements*.txt, setup.py, setup.cfg or %s with a [build-system] table found", PyProjectTomlFile)
+			}
+		}
+
+		pythonVersion := pythonversion.ResolveEntry(logger, proj.PythonVersionEntry, pythonversion.Requirement{})
 
 		requirements := []packit.BuildPlanRequirement{
 			{
 				Name: CPython,
 				Metadata: build.BuildPlanMetadata{
-					Build: true,
+					Build:         true,
+					Version:       pythonVersion.Version,
+					VersionSource: pythonVersion.Source,
 				},
 			},
 		}
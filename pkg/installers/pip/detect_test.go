@@ -5,28 +5,42 @@
 package pip_test
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	. "github.com/onsi/gomega"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pip"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 )
 
 func testDetect(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
 
+		buffer *bytes.Buffer
+
+		workingDir    string
 		detect        packit.DetectFunc
 		detectContext packit.DetectContext
 	)
 
 	it.Before(func() {
-		detect = pip.Detect()
-		detectContext = packit.DetectContext{}
+		workingDir = t.TempDir()
+		Expect(os.WriteFile(filepath.Join(workingDir, "requirements.txt"), []byte{}, os.ModePerm)).To(Succeed())
+
+		buffer = bytes.NewBuffer(nil)
+		detect = pip.Detect(project.NewLoader(), scribe.NewEmitter(buffer))
+		detectContext = packit.DetectContext{
+			WorkingDir: workingDir,
+		}
 	})
 
 	context("detection", func() {
@@ -85,7 +99,7 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 					t.Setenv(pip.EnvVersion, "2.11")
 				})
 
-				it("selects the version X.Y.0", func() {
+				it("requires the version as is, it is resolved to X.Y.0 at build time", func() {
 					result, err := detect(detectContext)
 
 					Expect(err).NotTo(HaveOccurred())
@@ -104,7 +118,7 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 							{
 								Name: pip.Pip,
 								Metadata: build.BuildPlanMetadata{
-									Version:       "2.11.0",
+									Version:       "2.11",
 									VersionSource: pip.EnvVersion,
 								},
 							},
@@ -180,5 +194,143 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("project files", func() {
+			it.Before(func() {
+				Expect(os.Remove(filepath.Join(workingDir, "requirements.txt"))).To(Succeed())
+			})
+
+			for _, name := range []string{"requirements-dev.txt", "setup.py", "setup.cfg"} {
+				context("with "+name, func() {
+					it.Before(func() {
+						Expect(os.WriteFile(filepath.Join(workingDir, name), []byte{}, os.ModePerm)).To(Succeed())
+					})
+
+					it("passes detection", func() {
+						result, err := detect(detectContext)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Plan.Provides).To(Equal([]packit.BuildPlanProvision{{Name: pip.Pip}}))
+					})
+				})
+			}
+
+			context("with a PEP 517 pyproject.toml", func() {
+				it.Before(func() {
+					Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`
+[build-system]
+requires = ["setuptools"]
+build-backend = "setuptools.build_meta"
+`), os.ModePerm)).To(Succeed())
+				})
+
+				it("passes detection", func() {
+					result, err := detect(detectContext)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result.Plan.Provides).To(Equal([]packit.BuildPlanProvision{{Name: pip.Pip}}))
+				})
+			})
+
+			context("with a pyproject.toml without build system", func() {
+				it.Before(func() {
+					Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`
+[tool.black]
+line-length = 88
+`), os.ModePerm)).To(Succeed())
+				})
+
+				it("fails detection", func() {
+					_, err := detect(detectContext)
+					Expect(err).To(MatchError(packit.Fail.WithMessage("no requirements*.txt, setup.py, setup.cfg or pyproject.toml with a [build-system] table found")))
+				})
+			})
+
+			context("without any project file", func() {
+				it("fails detection", func() {
+					_, err := detect(detectContext)
+					Expect(err).To(MatchError(packit.Fail.WithMessage("no requirements*.txt, setup.py, setup.cfg or pyproject.toml with a [build-system] table found")))
+				})
+
+				context("when BP_PYTHON_INSTALLERS_ALWAYS_DETECT is set", func() {
+					it.Before(func() {
+						t.Setenv(pip.EnvAlwaysDetect, "true")
+					})
+
+					it("passes detection", func() {
+						result, err := detect(detectContext)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(result.Plan.Provides).To(Equal([]packit.BuildPlanProvision{{Name: pip.Pip}}))
+					})
+				})
+			})
+
+			context("failure cases", func() {
+				context("when the pyproject.toml is malformed", func() {
+					it.Before(func() {
+						Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte("%%%"), os.ModePerm)).To(Succeed())
+					})
+
+					it("returns an error", func() {
+						_, err := detect(detectContext)
+						Expect(err).To(MatchError(ContainSubstring("toml")))
+					})
+				})
+
+				context("when BP_PYTHON_INSTALLERS_ALWAYS_DETECT is invalid", func() {
+					it.Before(func() {
+						t.Setenv(pip.EnvAlwaysDetect, "not-a-bool")
+					})
+
+					it("returns an error", func() {
+						_, err := detect(detectContext)
+						Expect(err).To(MatchError(ContainSubstring(`invalid value "not-a-bool" for BP_PYTHON_INSTALLERS_ALWAYS_DETECT`)))
+					})
+				})
+			})
+		})
+	})
+
+	context("with .python-version", func() {
+		it.Before(func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.12\n"), os.ModePerm)).To(Succeed())
+		})
+
+		it("requires the pinned version of cpython", func() {
+			result, err := detect(detectContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Plan.Requires).To(Equal([]packit.BuildPlanRequirement{
+				{
+					Name: pip.CPython,
+					Metadata: build.BuildPlanMetadata{
+						Build:         true,
+						Version:       "3.12",
+						VersionSource: ".python-version",
+					},
+				},
+			}))
+		})
+	})
+
+	context("when BP_PYTHON_PROJECT_PATH points to a sub-directory", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+			Expect(os.Rename(filepath.Join(workingDir, "requirements.txt"), filepath.Join(workingDir, "services", "api", "requirements.txt"))).To(Succeed())
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+		})
+
+		it("detects the project in the sub-directory", func() {
+			_, err := detect(detectContext)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		context("when the sub-directory does not exist", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+			})
+
+			it("returns an error", func() {
+				_, err := detect(detectContext)
+				Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`)))
+			})
+		})
 	})
 }
@@ -5,11 +5,11 @@
 package pip
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
 )
@@ -17,26 +17,28 @@ import (
 // PipInstallProcess implements the InstallProcess interface.
 type PipInstallProcess struct {
 	executable executable.Executable
+	logger     scribe.Emitter
 }
 
 // NewPipInstallProcess creates an instance of the PipInstallProcess given an Executable that runs `python`.
-func NewPipInstallProcess(executable executable.Executable) PipInstallProcess {
+func NewPipInstallProcess(executable executable.Executable, logger scribe.Emitter) PipInstallProcess {
 	return PipInstallProcess{
 		executable: executable,
+		logger:     logger,
 	}
 }
 
 // Execute installs the pip binary from source code located in the given srcPath into the a layer path designated by targetLayerPath.
 func (p PipInstallProcess) Execute(srcPath, targetLayerPath string) error {
-	buffer := bytes.NewBuffer(nil)
+	output, buffer := executable.NewOutputWriter(p.logger)
 
 	err := p.executable.Execute(pexec.Execution{
 		// Install pip from source with the pip that comes pre-installed with cpython
 		Args: []string{"-m", "pip", "install", srcPath, "--user", "--no-index", fmt.Sprintf("--find-links=%s", srcPath)},
 		// Set the PYTHONUSERBASE to ensure that pip is installed to the newly created target layer.
 		Env:    append(os.Environ(), fmt.Sprintf("PYTHONUSERBASE=%s", targetLayerPath)),
-		Stdout: buffer,
-		Stderr: buffer,
+		Stdout: output,
+		Stderr: output,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to configure pip:\n%s\nerror: %w", buffer.String(), err)
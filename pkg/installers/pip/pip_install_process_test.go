@@ -5,12 +5,14 @@
 package pip_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
@@ -26,6 +28,7 @@ func testPipInstallProcess(t *testing.T, context spec.G, it spec.S) {
 		srcLayerPath    string
 		targetLayerPath string
 		executable      *fakes.Executable
+		buffer          *bytes.Buffer
 
 		pipInstallProcess pip.PipInstallProcess
 	)
@@ -39,8 +42,9 @@ func testPipInstallProcess(t *testing.T, context spec.G, it spec.S) {
 		Expect(err).NotTo(HaveOccurred())
 
 		executable = &fakes.Executable{}
+		buffer = bytes.NewBuffer(nil)
 
-		pipInstallProcess = pip.NewPipInstallProcess(executable)
+		pipInstallProcess = pip.NewPipInstallProcess(executable, scribe.NewEmitter(buffer))
 	})
 
 	context("Execute", func() {
@@ -54,6 +58,27 @@ func testPipInstallProcess(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("when the log level is debug", func() {
+			it.Before(func() {
+				pipInstallProcess = pip.NewPipInstallProcess(executable, scribe.NewEmitter(buffer).WithLevel("DEBUG"))
+				executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+					_, err := fmt.Fprintln(execution.Stdout, "stdout output")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = fmt.Fprintln(execution.Stderr, "stderr output")
+					Expect(err).NotTo(HaveOccurred())
+					return nil
+				}
+			})
+
+			it("streams the process output", func() {
+				err := pipInstallProcess.Execute(srcLayerPath, targetLayerPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring("      stdout output\n"))
+				Expect(buffer.String()).To(ContainSubstring("      stderr output\n"))
+			})
+		})
+
 		context("failure cases", func() {
 			context("the pip install process fails", func() {
 				it.Before(func() {
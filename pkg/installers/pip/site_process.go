@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
 )
@@ -17,18 +18,20 @@ import (
 // SiteProcess implements the Executable interface.
 type SiteProcess struct {
 	executable executable.Executable
+	logger     scribe.Emitter
 }
 
 // NewSiteProcess creates an instance of the SiteProcess given an Executable that runs `python`
-func NewSiteProcess(executable executable.Executable) SiteProcess {
+func NewSiteProcess(executable executable.Executable, logger scribe.Emitter) SiteProcess {
 	return SiteProcess{
 		executable: executable,
+		logger:     logger,
 	}
 }
 
 // Execute runs a python command to locate the site packages within the pip targetLayerPath.
 func (p SiteProcess) Execute(targetLayerPath string) (string, error) {
-	buffer := bytes.NewBuffer(nil)
+	output, buffer := executable.NewOutputWriter(p.logger)
 	sitePackagesPath := bytes.NewBuffer(nil)
 
 	err := p.executable.Execute(pexec.Execution{
@@ -37,7 +40,7 @@ func (p SiteProcess) Execute(targetLayerPath string) (string, error) {
 		// Set the PYTHONUSERBASE to ensure that we are looking at the pip layer for user level packages.
 		Env:    append(os.Environ(), fmt.Sprintf("PYTHONUSERBASE=%s", targetLayerPath)),
 		Stdout: sitePackagesPath,
-		Stderr: buffer,
+		Stderr: output,
 	})
 
 	if err != nil {
@@ -5,6 +5,7 @@
 package pip_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
@@ -26,6 +28,7 @@ func testSiteProcess(t *testing.T, context spec.G, it spec.S) {
 
 		targetLayerPath string
 		executable      *fakes.Executable
+		buffer          *bytes.Buffer
 
 		siteProcess pip.SiteProcess
 	)
@@ -36,6 +39,7 @@ func testSiteProcess(t *testing.T, context spec.G, it spec.S) {
 		Expect(err).NotTo(HaveOccurred())
 
 		executable = &fakes.Executable{}
+		buffer = bytes.NewBuffer(nil)
 		executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
 			if execution.Stdout != nil {
 				_, err := fmt.Fprint(execution.Stdout, targetLayerPath, "/pip/lib/python/site-packages")
@@ -44,7 +48,7 @@ func testSiteProcess(t *testing.T, context spec.G, it spec.S) {
 			return nil
 		}
 
-		siteProcess = pip.NewSiteProcess(executable)
+		siteProcess = pip.NewSiteProcess(executable, scribe.NewEmitter(buffer))
 	})
 
 	it.After(func() {
@@ -6,24 +6,28 @@ package pipenv
 
 import (
 	"fmt"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/paketo-buildpacks/packit/v2"
 	"github.com/paketo-buildpacks/packit/v2/draft"
-	"github.com/paketo-buildpacks/packit/v2/sbom"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/interpreter"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/virtualenv"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
 )
 
 //go:generate faux --interface InstallProcess --output fakes/install_process.go
 //go:generate faux --interface SitePackageProcess --output fakes/site_package_process.go
+//go:generate faux --interface VirtualenvInstallProcess --output fakes/virtualenv_install_process.go
+//go:generate faux --interface InterpreterProcess --output fakes/interpreter_process.go
 
 // InstallProcess defines the interface for installing the pipenv dependency into a layer.
 type InstallProcess interface {
-	Execute(version, destLayerPath, pipLayerPath string) error
+	Execute(requirements []string, destLayerPath, pipLayerPath string) error
 }
 
 // SitePackageProcess defines the interface for looking up site packages within a layer.
@@ -31,12 +35,26 @@ type SitePackageProcess interface {
 	Execute(targetLayerPath string) (string, error)
 }
 
+// VirtualenvInstallProcess defines the interface for installing the pipenv
+// dependency into its own virtual environment within a layer.
+type VirtualenvInstallProcess interface {
+	Execute(targetLayerPath, pipLayerPath string, requirements []string, entrypoints ...string) error
+}
+
+// InterpreterProcess defines the interface for identifying the python
+// interpreter the layer is built with.
+type InterpreterProcess interface {
+	Execute() (string, error)
+}
+
 // PipEnvBuildParameters encapsulates the pip specific parameters for the
 // Build function
 type PipEnvBuildParameters struct {
-	DependencyManager  dependency.DependencyManager
-	InstallProcess     InstallProcess
-	SitePackageProcess SitePackageProcess
+	DependencyManager        dependency.DependencyManager
+	InstallProcess           InstallProcess
+	SitePackageProcess       SitePackageProcess
+	VirtualenvInstallProcess VirtualenvInstallProcess
+	InterpreterProcess       InterpreterProcess
 }
 
 // Build will return a packit.BuildFunc that will be invoked during the build
@@ -52,6 +70,8 @@ func Build(
 	return func(context packit.BuildContext) (packit.BuildResult, error) {
 		installProcess := buildParameters.InstallProcess
 		siteProcess := buildParameters.SitePackageProcess
+		virtualenvInstallProcess := buildParameters.VirtualenvInstallProcess
+		interpreterProcess := buildParameters.InterpreterProcess
 		dependencyManager := buildParameters.DependencyManager
 		sbomGenerator := parameters.SbomGenerator
 		clock := parameters.Clock
@@ -66,14 +86,25 @@ func Build(
 
 		logger.Candidates(sortedEntries)
 
-		version, _ := entry.Metadata["version"].(string)
+		pythonInterpreter, err := interpreterProcess.Execute()
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
 
-		dependency, err := dependencyManager.Resolve(filepath.Join(context.CNBPath, "buildpack.toml"), entry.Name, version, context.Stack)
+		dependency, err := dependency.ResolveForPython(dependencyManager, logger, clock, context.CNBPath, entry.Name, entry, context.Stack, interpreter.Version(pythonInterpreter))
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
-		logger.SelectedDependency(entry, dependency, clock.Now())
+		installMode, err := virtualenv.GetInstallMode(EnvUserInstall)
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		layerMetadata := build.NewLayerMetadata(dependency, context.BuildpackInfo.Version, map[string]string{
+			InstallModeKey: installMode,
+			InterpreterKey: pythonInterpreter,
+		})
 
 		legacySBOM := dependencyManager.GenerateBillOfMaterials(dependency)
 		launch, build := planner.MergeLayerTypes(Pipenv, context.Plan.Entries)
@@ -93,11 +124,16 @@ func Build(
 			return packit.BuildResult{}, err
 		}
 
-		cachedChecksum, ok := pipenvLayer.Metadata[DependencyChecksumKey].(string)
-		if ok && cachedChecksum == dependency.Checksum {
+		reusable, reason := layerMetadata.Matches(pipenvLayer.Metadata)
+		if reusable {
 			logger.Process("Reusing cached layer %s", pipenvLayer.Path)
 			pipenvLayer.Launch, pipenvLayer.Build, pipenvLayer.Cache = launch, build, build
 
+			pipenvLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, pipenvLayer.Path, context.BuildpackInfo.SBOMFormats)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
 			return packit.BuildResult{
 				Layers: []packit.Layer{pipenvLayer},
 				Build:  buildMetadata,
@@ -105,6 +141,10 @@ func Build(
 			}, nil
 		}
 
+		if reason != "" {
+			logger.Process("Rebuilding cached layer %s: %s", pipenvLayer.Path, reason)
+		}
+
 		pipenvLayer, err = pipenvLayer.Reset()
 		if err != nil {
 			return packit.BuildResult{}, err
@@ -113,7 +153,7 @@ func Build(
 		pipenvLayer.Launch, pipenvLayer.Build, pipenvLayer.Cache = launch, build, build
 
 		logger.Process("Executing build process")
-		logger.Subprocess(fmt.Sprintf("Installing Pipenv %s", dependency.Version))
+		logger.Subprocess(fmt.Sprintf("Installing Pipenv %s (%s install)", dependency.Version, installMode))
 
 		pipLayer, err := context.Layers.Get(Pip)
 		if err != nil {
@@ -121,22 +161,18 @@ func Build(
 		}
 
 		duration, err := clock.Measure(func() error {
-			return installProcess.Execute(dependency.Version, pipenvLayer.Path, pipLayer.Path)
-		})
+			requirements, err := wheelhouse.Requirements(context, dependencyManager, logger, dependency.ID, dependency.Version, interpreter.ABI(pythonInterpreter), Requirement(dependency.Version))
+			if err != nil {
+				return err
+			}
 
-		if err != nil {
-			return packit.BuildResult{}, err
-		}
-
-		logger.Action("Completed in %s", duration.Round(time.Millisecond))
-		logger.Break()
+			if installMode == virtualenv.ModeVirtualenv {
+				return virtualenvInstallProcess.Execute(pipenvLayer.Path, pipLayer.Path, requirements, Pipenv)
+			}
 
-		logger.GeneratingSBOM(pipenvLayer.Path)
-		var sbomContent sbom.SBOM
-		duration, err = clock.Measure(func() error {
-			sbomContent, err = sbomGenerator.GenerateFromDependency(dependency, pipenvLayer.Path)
-			return err
+			return installProcess.Execute(requirements, pipenvLayer.Path, pipLayer.Path)
 		})
+
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
@@ -144,27 +180,26 @@ func Build(
 		logger.Action("Completed in %s", duration.Round(time.Millisecond))
 		logger.Break()
 
-		logger.FormattingSBOM(context.BuildpackInfo.SBOMFormats...)
-		pipenvLayer.SBOM, err = sbomContent.InFormats(context.BuildpackInfo.SBOMFormats...)
+		pipenvLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, pipenvLayer.Path, context.BuildpackInfo.SBOMFormats)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
-		pipenvLayer.Metadata = map[string]interface{}{
-			DependencyChecksumKey: dependency.Checksum,
-		}
+		pipenvLayer.Metadata = layerMetadata.ToMap()
 
-		// Look up the site packages path and prepend it onto $PYTHONPATH
-		sitePackagesPath, err := siteProcess.Execute(pipenvLayer.Path)
-		if err != nil {
-			return packit.BuildResult{}, err
-		}
+		if installMode == virtualenv.ModeUser {
+			// Look up the site packages path and prepend it onto $PYTHONPATH
+			sitePackagesPath, err := siteProcess.Execute(pipenvLayer.Path)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
 
-		if sitePackagesPath == "" {
-			return packit.BuildResult{}, fmt.Errorf("pipenv installation failed: site packages are missing from the pipenv layer")
-		}
+			if sitePackagesPath == "" {
+				return packit.BuildResult{}, fmt.Errorf("pipenv installation failed: site packages are missing from the pipenv layer")
+			}
 
-		pipenvLayer.SharedEnv.Prepend("PYTHONPATH", strings.TrimRight(sitePackagesPath, "\n"), ":")
+			pipenvLayer.SharedEnv.Prepend("PYTHONPATH", strings.TrimRight(sitePackagesPath, "\n"), ":")
+		}
 
 		logger.EnvironmentVariables(pipenvLayer)
 
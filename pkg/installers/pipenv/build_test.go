@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
@@ -27,6 +28,8 @@ import (
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pipenv"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pipenv/fakes"
 	sbomfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/sbomtest"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
 
 	. "github.com/onsi/gomega"
 )
@@ -39,10 +42,15 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		cnbDir    string
 
 		dependencyManager *dependencyfakes.DependencyManager
+		resolvedVersions  map[string]string
+		wheelhouses       map[string]postal.Dependency
 		installProcess    *fakes.InstallProcess
 		siteProcess       *fakes.SitePackageProcess
 		sbomGenerator     *sbomfakes.SBOMGenerator
 
+		virtualenvInstallProcess *fakes.VirtualenvInstallProcess
+		interpreterProcess       *fakes.InterpreterProcess
+
 		buffer *bytes.Buffer
 
 		logger scribe.Emitter
@@ -65,6 +73,22 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			Version:  "pipenv-dependency-version",
 		}
 
+		// buildpack.toml has no wheelhouse unless a test adds one
+		resolvedVersions = map[string]string{}
+		wheelhouses = map[string]postal.Dependency{}
+		dependencyManager.ResolveCall.Stub = func(path, id, version, stack string) (postal.Dependency, error) {
+			resolvedVersions[id] = version
+			if id == "pipenv" {
+				return dependencyManager.ResolveCall.Returns.Dependency, dependencyManager.ResolveCall.Returns.Error
+			}
+
+			wheelhouse, ok := wheelhouses[id]
+			if !ok {
+				return postal.Dependency{}, &postal.ErrNoDeps{}
+			}
+			return wheelhouse, nil
+		}
+
 		// Legacy SBOM
 		dependencyManager.GenerateBillOfMaterialsCall.Returns.BOMEntrySlice = []packit.BOMEntry{
 			{
@@ -82,6 +106,9 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		installProcess = &fakes.InstallProcess{}
 		siteProcess = &fakes.SitePackageProcess{}
+		virtualenvInstallProcess = &fakes.VirtualenvInstallProcess{}
+		interpreterProcess = &fakes.InterpreterProcess{}
+		interpreterProcess.ExecuteCall.Returns.String = "3.8-cpython-38-x86_64-linux-gnu"
 
 		// Syft SBOM
 		sbomGenerator = &sbomfakes.SBOMGenerator{}
@@ -97,6 +124,8 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 				dependencyManager,
 				installProcess,
 				siteProcess,
+				virtualenvInstallProcess,
+				interpreterProcess,
 			},
 			build.CommonBuildParameters{
 				SbomGenerator: sbomGenerator,
@@ -136,10 +165,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		Expect(layer.Path).To(Equal(filepath.Join(layersDir, "pipenv")))
 
-		Expect(layer.SharedEnv).To(HaveLen(2))
-		Expect(layer.SharedEnv["PYTHONPATH.delim"]).To(Equal(":"))
-		Expect(layer.SharedEnv["PYTHONPATH.prepend"]).To(Equal(filepath.Join(layersDir, "pipenv", "lib/python3.8/site-packages")))
-
+		Expect(layer.SharedEnv).To(BeEmpty())
 		Expect(layer.BuildEnv).To(BeEmpty())
 		Expect(layer.LaunchEnv).To(BeEmpty())
 		Expect(layer.ProcessLaunchEnv).To(BeEmpty())
@@ -148,8 +174,17 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(layer.Launch).To(BeFalse())
 		Expect(layer.Cache).To(BeFalse())
 
-		Expect(layer.Metadata).To(HaveLen(1))
-		Expect(layer.Metadata["dependency_checksum"]).To(Equal("pipenv-dependency-sha"))
+		Expect(layer.Metadata).To(Equal(map[string]interface{}{
+			"schema-version":      1,
+			"buildpack-version":   "some-version",
+			"dependency-checksum": "pipenv-dependency-sha",
+			"dependency-version":  "pipenv-dependency-version",
+			"dependency-arch":     runtime.GOARCH,
+			"install-options": map[string]interface{}{
+				"install-mode": "virtualenv",
+				"interpreter":  "3.8-cpython-38-x86_64-linux-gnu",
+			},
+		}))
 
 		Expect(layer.SBOM.Formats()).To(HaveLen(2))
 		var actualExtensions []string
@@ -159,8 +194,10 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(actualExtensions).To(ConsistOf("cdx.json", "spdx.json"))
 
 		Expect(dependencyManager.ResolveCall.Receives.Path).To(Equal(filepath.Join(cnbDir, "buildpack.toml")))
-		Expect(dependencyManager.ResolveCall.Receives.Id).To(Equal("pipenv"))
-		Expect(dependencyManager.ResolveCall.Receives.Version).To(Equal(""))
+		Expect(resolvedVersions).To(Equal(map[string]string{
+			"pipenv":      "",
+			"pipenv-cp38": "pipenv-dependency-version",
+		}))
 		Expect(dependencyManager.ResolveCall.Receives.Stack).To(Equal("some-stack"))
 
 		Expect(dependencyManager.GenerateBillOfMaterialsCall.Receives.Dependencies).To(Equal([]postal.Dependency{
@@ -176,9 +213,92 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		Expect(sbomGenerator.GenerateFromDependencyCall.Receives.Dir).To(Equal(filepath.Join(layersDir, "pipenv")))
 
-		Expect(installProcess.ExecuteCall.Receives.Version).To(ContainSubstring("pipenv-dependency-version"))
-		Expect(installProcess.ExecuteCall.Receives.DestLayerPath).To(Equal(filepath.Join(layersDir, "pipenv")))
-		Expect(installProcess.ExecuteCall.Receives.PipLayerPath).To(Equal(filepath.Join(layersDir, "pip")))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.TargetLayerPath).To(Equal(filepath.Join(layersDir, "pipenv")))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.PipLayerPath).To(Equal(filepath.Join(layersDir, "pip")))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.Requirements).To(Equal([]string{"pipenv==pipenv-dependency-version"}))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.Entrypoints).To(Equal([]string{"pipenv"}))
+
+		Expect(installProcess.ExecuteCall.CallCount).To(Equal(0))
+		Expect(siteProcess.ExecuteCall.CallCount).To(Equal(0))
+
+		Expect(buffer.String()).To(ContainSubstring("Installing Pipenv pipenv-dependency-version (virtualenv install)"))
+		Expect(buffer.String()).To(ContainSubstring("No wheelhouse of pipenv pipenv-dependency-version for the python interpreter, installing from PyPI"))
+	})
+
+	context("when BP_PIPENV_USER_INSTALL is true", func() {
+		it.Before(func() {
+			t.Setenv("BP_PIPENV_USER_INSTALL", "true")
+		})
+
+		it("installs pipenv in the user site packages of the layer", func() {
+			result, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(HaveLen(1))
+			layer := result.Layers[0]
+
+			Expect(layer.SharedEnv).To(HaveLen(2))
+			Expect(layer.SharedEnv["PYTHONPATH.delim"]).To(Equal(":"))
+			Expect(layer.SharedEnv["PYTHONPATH.prepend"]).To(Equal(filepath.Join(layersDir, "pipenv", "lib/python3.8/site-packages")))
+
+			Expect(layer.Metadata["install-options"]).To(Equal(map[string]interface{}{
+				"install-mode": "user",
+				"interpreter":  "3.8-cpython-38-x86_64-linux-gnu",
+			}))
+
+			Expect(installProcess.ExecuteCall.Receives.Requirements).To(Equal([]string{"pipenv==pipenv-dependency-version"}))
+			Expect(installProcess.ExecuteCall.Receives.DestLayerPath).To(Equal(filepath.Join(layersDir, "pipenv")))
+			Expect(installProcess.ExecuteCall.Receives.PipLayerPath).To(Equal(filepath.Join(layersDir, "pip")))
+
+			Expect(virtualenvInstallProcess.ExecuteCall.CallCount).To(Equal(0))
+
+			Expect(buffer.String()).To(ContainSubstring("Installing Pipenv pipenv-dependency-version (user install)"))
+		})
+	})
+
+	context("when buildpack.toml has a wheelhouse for the python interpreter", func() {
+		it.Before(func() {
+			wheelhouses["pipenv-cp38"] = postal.Dependency{
+				ID:      "pipenv-cp38",
+				Version: "pipenv-dependency-version",
+			}
+		})
+
+		it("installs pipenv from the wheelhouse", func() {
+			_, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dependencyManager.DeliverCall.Receives.Dependency.ID).To(Equal("pipenv-cp38"))
+			Expect(dependencyManager.DeliverCall.Receives.DestinationPath).To(Equal(filepath.Join(layersDir, "pipenv-wheelhouse")))
+
+			Expect(virtualenvInstallProcess.ExecuteCall.Receives.Requirements).To(Equal(wheelhouse.InstallArgs(filepath.Join(layersDir, "pipenv-wheelhouse"))))
+
+			Expect(buffer.String()).To(ContainSubstring("Installing from the wheelhouse pipenv-cp38"))
+		})
+
+		context("when BP_PIPENV_USER_INSTALL is true", func() {
+			it.Before(func() {
+				t.Setenv("BP_PIPENV_USER_INSTALL", "true")
+			})
+
+			it("installs pipenv from the wheelhouse in the user site packages of the layer", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(installProcess.ExecuteCall.Receives.Requirements).To(Equal(wheelhouse.InstallArgs(filepath.Join(layersDir, "pipenv-wheelhouse"))))
+			})
+		})
+
+		context("when the wheelhouse cannot be delivered", func() {
+			it.Before(func() {
+				dependencyManager.DeliverCall.Returns.Error = errors.New("failed to deliver the wheelhouse")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError("failed to deliver the wheelhouse"))
+			})
+		})
 	})
 
 	context("when build plan entries require pipenv at build/launch", func() {
@@ -239,9 +359,15 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 	context("when rebuilding a layer", func() {
 		it.Before(func() {
 			err := os.WriteFile(filepath.Join(layersDir, fmt.Sprintf("%s.toml", pipenv.Pipenv)), []byte(fmt.Sprintf(`[metadata]
-			%s = "pipenv-dependency-sha"
-			built_at = "some-build-time"
-			`, pipenv.DependencyChecksumKey)), os.ModePerm)
+			schema-version = 1
+			buildpack-version = "some-version"
+			dependency-checksum = "pipenv-dependency-sha"
+			dependency-version = "pipenv-dependency-version"
+			dependency-arch = "%s"
+			[metadata.install-options]
+			install-mode = "virtualenv"
+			interpreter = "3.8-cpython-38-x86_64-linux-gnu"
+			`, runtime.GOARCH)), os.ModePerm)
 			Expect(err).NotTo(HaveOccurred())
 
 			buildContext.Plan.Entries[0].Metadata = make(map[string]interface{})
@@ -264,11 +390,97 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 			Expect(buffer.String()).To(ContainSubstring("Reusing cached layer"))
 
+			sbomtest.ExpectLayerSBOM(t, result.Layers[0], sbomGenerator, filepath.Join(layersDir, "pipenv"))
+
 			Expect(installProcess.ExecuteCall.CallCount).To(Equal(0))
+			Expect(virtualenvInstallProcess.ExecuteCall.CallCount).To(Equal(0))
+		})
+
+		context("when generating the SBOM of the cached layer returns an error", func() {
+			it.Before(func() {
+				sbomGenerator.GenerateFromDependencyCall.Returns.Error = errors.New("failed to generate SBOM")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to generate SBOM")))
+			})
+		})
+
+		context("when the cached layer was installed with a different install mode", func() {
+			it.Before(func() {
+				t.Setenv("BP_PIPENV_USER_INSTALL", "true")
+			})
+
+			it("reinstalls pipenv", func() {
+				result, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.Layers).To(HaveLen(1))
+				Expect(result.Layers[0].Metadata["install-options"]).To(HaveKeyWithValue("install-mode", "user"))
+
+				Expect(buffer.String()).To(ContainSubstring(`install-mode changed from "virtualenv" to "user"`))
+				Expect(buffer.String()).NotTo(ContainSubstring("Reusing cached layer"))
+				Expect(installProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
+		})
+
+		context("when the python interpreter changed", func() {
+			it.Before(func() {
+				interpreterProcess.ExecuteCall.Returns.String = "3.9-cpython-39-x86_64-linux-gnu"
+			})
+
+			it("reinstalls pipenv and explains why", func() {
+				result, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.Layers).To(HaveLen(1))
+				Expect(result.Layers[0].Metadata["install-options"]).To(HaveKeyWithValue("interpreter", "3.9-cpython-39-x86_64-linux-gnu"))
+
+				Expect(buffer.String()).To(ContainSubstring(fmt.Sprintf(
+					`Rebuilding cached layer %s: interpreter changed from "3.8-cpython-38-x86_64-linux-gnu" to "3.9-cpython-39-x86_64-linux-gnu"`,
+					filepath.Join(layersDir, "pipenv"),
+				)))
+				Expect(buffer.String()).NotTo(ContainSubstring("Reusing cached layer"))
+				Expect(virtualenvInstallProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
 		})
 	})
 
 	context("failure cases", func() {
+		context("when the python interpreter cannot be identified", func() {
+			it.Before(func() {
+				interpreterProcess.ExecuteCall.Returns.Error = errors.New("failed to identify python interpreter")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to identify python interpreter")))
+			})
+		})
+
+		context("when BP_PIPENV_USER_INSTALL is invalid", func() {
+			it.Before(func() {
+				t.Setenv("BP_PIPENV_USER_INSTALL", "not-a-bool")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("invalid value for BP_PIPENV_USER_INSTALL")))
+			})
+		})
+
+		context("when pipenv cannot be installed in a virtual environment", func() {
+			it.Before(func() {
+				virtualenvInstallProcess.ExecuteCall.Returns.Error = errors.New("failed to create virtual environment")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to create virtual environment")))
+			})
+		})
+
 		context("when dependency resolution fails", func() {
 			it.Before(func() {
 				dependencyManager.ResolveCall.Returns.Error = errors.New("failed to resolve dependency")
@@ -315,6 +527,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		context("when dependency cannot be installed", func() {
 			it.Before(func() {
+				t.Setenv("BP_PIPENV_USER_INSTALL", "true")
 				installProcess.ExecuteCall.Returns.Error = errors.New("failed to install dependency")
 			})
 			it("returns an error", func() {
@@ -326,6 +539,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		context("when the site packages cannot be found", func() {
 			it.Before(func() {
+				t.Setenv("BP_PIPENV_USER_INSTALL", "true")
 				siteProcess.ExecuteCall.Returns.Error = errors.New("failed to find site-packages dir")
 			})
 
@@ -337,6 +551,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		context("when the layer does not have a site-packages directory", func() {
 			it.Before(func() {
+				t.Setenv("BP_PIPENV_USER_INSTALL", "true")
 				siteProcess.ExecuteCall.Returns.String = ""
 			})
 
@@ -5,11 +5,29 @@
 package pipenv
 
 const (
-	Pipenv                = "pipenv"
-	DependencyChecksumKey = "dependency_checksum"
-	CPython               = "cpython"
-	Pip                   = "pip"
-	EnvVersion            = "BP_PIPENV_VERSION"
+	Pipenv     = "pipenv"
+	CPython    = "cpython"
+	Pip        = "pip"
+	EnvVersion = "BP_PIPENV_VERSION"
+
+	// PipfileName is the name of the pipenv project file
+	PipfileName = "Pipfile"
+
+	// LockfileName is the name of the pipenv lock file
+	LockfileName = "Pipfile.lock"
+
+	// EnvUserInstall selects the legacy `pip install --user` installation
+	// instead of an isolated virtual environment.
+	EnvUserInstall = "BP_PIPENV_USER_INSTALL"
+
+	// InstallModeKey is the name of the install option in the pipenv layer
+	// metadata whose value is the install mode used.
+	InstallModeKey = "install-mode"
+
+	// InterpreterKey is the name of the install option in the pipenv layer
+	// metadata whose value identifies the python interpreter the layer was
+	// built with.
+	InterpreterKey = "interpreter"
 )
 
 var Priorities = []interface{}{EnvVersion}
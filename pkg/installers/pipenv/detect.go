@@ -8,27 +8,70 @@ import (
 	"os"
 
 	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pip"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pythonversion"
 )
 
 // Detect will return a packit.DetectFunc that will be invoked during the
 // detect phase of the buildpack lifecycle.
 //
-// This buildpack always passes detection and will contribute a Build Plan that
-// provides pipenv.
+// Detection passes when the application contains a Pipfile or a Pipfile.lock
+// and will contribute a Build Plan that provides pipenv. Setting
+// $BP_PYTHON_INSTALLERS_ALWAYS_DETECT to true makes detection always pass.
+//
+// The python version declared in the Pipfile, or failing that the one
+// recorded in the Pipfile.lock, is required from cpython. A version pinned in
+// .python-version takes precedence when it satisfies the declared one.
 //
 // If a version is provided via the $BP_PIPENV_VERSION environment variable,
 // that version of pipenv will be a requirement.
-func Detect() packit.DetectFunc {
+func Detect(loader project.Loader, logger scribe.Emitter) packit.DetectFunc {
 	return func(context packit.DetectContext) (packit.DetectResult, error) {
+		proj, err := loader.Load(context.WorkingDir)
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
+
+		pipfile, err := proj.Pipfile()
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
+
+		pipfileLock, err := proj.PipfileLock()
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
+
+		alwaysDetect, err := pip.AlwaysDetect()
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
+
+		if !alwaysDetect && pipfile == nil && pipfileLock == nil {
+			return packit.DetectResult{}, packit.Fail.WithMessage("neither %s nor %s are present", PipfileName, LockfileName)
+		}
+
+		var declared pythonversion.Requirement
+
+		if version := pipfile.PythonVersion(); version != "" {
+			declared = pythonversion.Requirement{Version: version, Source: PipfileName}
+		} else if version := pipfileLock.PythonVersion(); version != "" {
+			declared = pythonversion.Requirement{Version: version, Source: LockfileName}
+		}
+
+		python := pythonversion.ResolveEntry(logger, proj.PythonVersionEntry, declared)
 
 		requirements := []packit.BuildPlanRequirement{
 			{
 				Name: CPython,
 				Metadata: build.BuildPlanMetadata{
-					Build: true,
+					Build:         true,
+					Version:       python.Version,
+					VersionSource: python.Source,
 				},
 			},
 		}
@@ -5,16 +5,19 @@
 package pipenv_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pipenv"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 
 	. "github.com/onsi/gomega"
 )
@@ -23,6 +26,8 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
 
+		buffer *bytes.Buffer
+
 		workingDir string
 		detect     packit.DetectFunc
 	)
@@ -33,7 +38,8 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		err := os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte{}, 0644)
 		Expect(err).NotTo(HaveOccurred())
 
-		detect = pipenv.Detect()
+		buffer = bytes.NewBuffer(nil)
+		detect = pipenv.Detect(project.NewLoader(), scribe.NewEmitter(buffer))
 	})
 
 	it("returns a plan that provides pipenv", func() {
@@ -111,4 +117,196 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("with only a Pipfile.lock", func() {
+		it.Before(func() {
+			Expect(os.Remove(filepath.Join(workingDir, "Pipfile"))).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile.lock"), []byte("{}"), 0644)).To(Succeed())
+		})
+
+		it("passes detection", func() {
+			result, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Plan.Provides).To(ContainElement(packit.BuildPlanProvision{Name: pipenv.Pipenv}))
+		})
+	})
+
+	context("without Pipfile nor Pipfile.lock", func() {
+		it.Before(func() {
+			Expect(os.Remove(filepath.Join(workingDir, "Pipfile"))).To(Succeed())
+		})
+
+		it("fails detection", func() {
+			_, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).To(MatchError(packit.Fail.WithMessage("neither Pipfile nor Pipfile.lock are present")))
+		})
+
+		context("when BP_PYTHON_INSTALLERS_ALWAYS_DETECT is set", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_INSTALLERS_ALWAYS_DETECT", "true")
+			})
+
+			it("passes detection", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan.Provides).To(ContainElement(packit.BuildPlanProvision{Name: pipenv.Pipenv}))
+			})
+		})
+	})
+
+	context("when the Pipfile requires a python version", func() {
+		it.Before(func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte(`[requires]
+python_version = "3.12"
+`), 0644)).To(Succeed())
+		})
+
+		it("requires that version of cpython", func() {
+			result, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Plan.Requires[0]).To(Equal(packit.BuildPlanRequirement{
+				Name: pipenv.CPython,
+				Metadata: build.BuildPlanMetadata{
+					Build:         true,
+					Version:       "3.12",
+					VersionSource: "Pipfile",
+				},
+			}))
+		})
+	})
+
+	context("when only the Pipfile.lock records a python version", func() {
+		it.Before(func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile.lock"), []byte(`{"default": {}, "_meta": {"requires": {"python_full_version": "3.11.4"}}}`), 0644)).To(Succeed())
+		})
+
+		it("requires that version of cpython", func() {
+			result, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Plan.Requires[0]).To(Equal(packit.BuildPlanRequirement{
+				Name: pipenv.CPython,
+				Metadata: build.BuildPlanMetadata{
+					Build:         true,
+					Version:       "3.11.4",
+					VersionSource: "Pipfile.lock",
+				},
+			}))
+		})
+	})
+
+	context("failure cases", func() {
+		context("when the Pipfile cannot be parsed", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte("%%%"), 0644)).To(Succeed())
+			})
+
+			it("returns an error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring("failed to parse Pipfile")))
+			})
+		})
+
+		context("when the Pipfile.lock cannot be parsed", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile.lock"), []byte("{"), 0644)).To(Succeed())
+			})
+
+			it("returns an error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring("failed to parse Pipfile.lock")))
+			})
+		})
+	})
+
+	context("with .python-version", func() {
+		it.Before(func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.12.4\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte(`[requires]
+python_version = "3.12"
+`), 0644)).To(Succeed())
+		})
+
+		it("prefers the pinned version when it satisfies the Pipfile", func() {
+			result, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Plan.Requires[0].Metadata).To(Equal(build.BuildPlanMetadata{
+				Build:         true,
+				Version:       "3.12.4",
+				VersionSource: ".python-version",
+			}))
+			Expect(buffer.String()).To(BeEmpty())
+		})
+
+		context("when it conflicts with the Pipfile", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte(`[requires]
+python_version = "3.11"
+`), 0644)).To(Succeed())
+			})
+
+			it("keeps the Pipfile version and warns", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan.Requires[0].Metadata).To(Equal(build.BuildPlanMetadata{
+					Build:         true,
+					Version:       "3.11",
+					VersionSource: "Pipfile",
+				}))
+				Expect(buffer.String()).To(ContainSubstring(`Warning: .python-version pins python 3.12.4 which does not satisfy "3.11" from Pipfile`))
+			})
+		})
+	})
+
+	context("when BP_PYTHON_PROJECT_PATH points to a sub-directory", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte(`[requires]
+python_version = "3.12"
+`), 0644)).To(Succeed())
+			Expect(os.Rename(filepath.Join(workingDir, "Pipfile"), filepath.Join(workingDir, "services", "api", "Pipfile"))).To(Succeed())
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+		})
+
+		it("detects the project in the sub-directory", func() {
+			result, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Plan.Requires[0].Metadata).To(Equal(build.BuildPlanMetadata{
+				Build:         true,
+				Version:       "3.12",
+				VersionSource: "Pipfile",
+			}))
+		})
+
+		context("when the sub-directory does not exist", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+			})
+
+			it("returns an error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`)))
+			})
+		})
+	})
 }
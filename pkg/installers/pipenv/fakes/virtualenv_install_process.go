@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import "sync"
+
+type VirtualenvInstallProcess struct {
+	ExecuteCall struct {
+		mutex     sync.Mutex
+		CallCount int
+		Receives  struct {
+			TargetLayerPath string
+			PipLayerPath    string
+			Requirements    []string
+			Entrypoints     []string
+		}
+		Returns struct {
+			Error error
+		}
+		Stub func(string, string, []string, ...string) error
+	}
+}
+
+func (f *VirtualenvInstallProcess) Execute(param1 string, param2 string, param3 []string, param4 ...string) error {
+	f.ExecuteCall.mutex.Lock()
+	defer f.ExecuteCall.mutex.Unlock()
+	f.ExecuteCall.CallCount++
+	f.ExecuteCall.Receives.TargetLayerPath = param1
+	f.ExecuteCall.Receives.PipLayerPath = param2
+	f.ExecuteCall.Receives.Requirements = param3
+	f.ExecuteCall.Receives.Entrypoints = param4
+	if f.ExecuteCall.Stub != nil {
+		return f.ExecuteCall.Stub(param1, param2, param3, param4...)
+	}
+	return f.ExecuteCall.Returns.Error
+}
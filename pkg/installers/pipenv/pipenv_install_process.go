@@ -5,39 +5,49 @@
 package pipenv
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pep440"
 )
 
 type PipenvInstallProcess struct {
 	executable executable.Executable
+	logger     scribe.Emitter
 }
 
 // NewPipenvInstallProcess creates a PipenvInstallProcess instance.
-func NewPipenvInstallProcess(executable executable.Executable) PipenvInstallProcess {
+func NewPipenvInstallProcess(executable executable.Executable, logger scribe.Emitter) PipenvInstallProcess {
 	return PipenvInstallProcess{
 		executable: executable,
+		logger:     logger,
 	}
 }
 
-// Execute installs the provided version of pipenv from the internet into the
-// layer path designated by targetLayerPath
-func (p PipenvInstallProcess) Execute(version, targetLayerPath, pipLayerPath string) error {
-	buffer := bytes.NewBuffer(nil)
+// Requirement returns the pip requirement pinning version of pipenv, the
+// version of buildpack.toml being converted back to PEP 440.
+func Requirement(version string) string {
+	return fmt.Sprintf("pipenv==%s", pep440.FromSemver(version))
+}
+
+// Execute installs the requirements of pipenv into the layer path designated
+// by targetLayerPath. The requirements are passed as is to pip install and
+// either pin the version of pipenv to fetch from PyPI or point to a wheelhouse.
+func (p PipenvInstallProcess) Execute(requirements []string, targetLayerPath, pipLayerPath string) error {
+	output, buffer := executable.NewOutputWriter(p.logger)
 
 	pipPath := fmt.Sprintf("PATH=%s", filepath.Join(pipLayerPath, "bin"))
 	err := p.executable.Execute(pexec.Execution{
-		Args: []string{"install", fmt.Sprintf("pipenv==%s", version), "--user"},
+		Args: append(append([]string{"install"}, requirements...), "--user"),
 		// Set the PYTHONUSERBASE to ensure that pipenv is installed to the newly created target layer.
 		Env:    append(os.Environ(), pipPath, fmt.Sprintf("PYTHONUSERBASE=%s", targetLayerPath)),
-		Stdout: buffer,
-		Stderr: buffer,
+		Stdout: output,
+		Stderr: output,
 	})
 
 	if err != nil {
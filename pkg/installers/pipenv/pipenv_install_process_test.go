@@ -5,6 +5,7 @@
 package pipenv_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
@@ -28,6 +30,7 @@ func testPipenvInstallProcess(t *testing.T, context spec.G, it spec.S) {
 		destLayerPath string
 		pipLayerPath  string
 		executable    *fakes.Executable
+		buffer        *bytes.Buffer
 
 		pipenvInstallProcess pipenv.PipenvInstallProcess
 	)
@@ -37,14 +40,15 @@ func testPipenvInstallProcess(t *testing.T, context spec.G, it spec.S) {
 		pipLayerPath = t.TempDir()
 
 		executable = &fakes.Executable{}
+		buffer = bytes.NewBuffer(nil)
 
-		pipenvInstallProcess = pipenv.NewPipenvInstallProcess(executable)
+		pipenvInstallProcess = pipenv.NewPipenvInstallProcess(executable, scribe.NewEmitter(buffer))
 	})
 
 	context("Execute", func() {
 		context("there is a pipenv dependency to install", func() {
 			it("installs it to the pipenv layer", func() {
-				err := pipenvInstallProcess.Execute(version, destLayerPath, pipLayerPath)
+				err := pipenvInstallProcess.Execute([]string{pipenv.Requirement(version)}, destLayerPath, pipLayerPath)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(executable.ExecuteCall.Receives.Execution.Env).To(Equal(append(os.Environ(),
@@ -55,6 +59,45 @@ func testPipenvInstallProcess(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("when the version is a post release", func() {
+			it("pins the PEP 440 version", func() {
+				err := pipenvInstallProcess.Execute([]string{pipenv.Requirement("2024.0.1+post1")}, destLayerPath, pipLayerPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executable.ExecuteCall.Receives.Execution.Args).To(Equal([]string{"install", "pipenv==2024.0.1.post1", "--user"}))
+			})
+		})
+
+		context("when installing from a wheelhouse", func() {
+			it("passes the wheelhouse options to pip", func() {
+				err := pipenvInstallProcess.Execute([]string{"--no-index", "--find-links", "some-dir"}, destLayerPath, pipLayerPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executable.ExecuteCall.Receives.Execution.Args).To(Equal([]string{"install", "--no-index", "--find-links", "some-dir", "--user"}))
+			})
+		})
+
+		context("when the log level is debug", func() {
+			it.Before(func() {
+				pipenvInstallProcess = pipenv.NewPipenvInstallProcess(executable, scribe.NewEmitter(buffer).WithLevel("DEBUG"))
+				executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+					_, err := fmt.Fprintln(execution.Stdout, "stdout output")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = fmt.Fprintln(execution.Stderr, "stderr output")
+					Expect(err).NotTo(HaveOccurred())
+					return nil
+				}
+			})
+
+			it("streams the process output", func() {
+				err := pipenvInstallProcess.Execute([]string{pipenv.Requirement(version)}, destLayerPath, pipLayerPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring("      stdout output\n"))
+				Expect(buffer.String()).To(ContainSubstring("      stderr output\n"))
+			})
+		})
+
 		context("failure cases", func() {
 			context("the install process fails", func() {
 				it.Before(func() {
@@ -68,7 +111,7 @@ func testPipenvInstallProcess(t *testing.T, context spec.G, it spec.S) {
 				})
 
 				it("returns an error", func() {
-					err := pipenvInstallProcess.Execute(version, destLayerPath, pipLayerPath)
+					err := pipenvInstallProcess.Execute([]string{pipenv.Requirement(version)}, destLayerPath, pipLayerPath)
 					Expect(err).To(MatchError(ContainSubstring("installing pipenv failed")))
 					Expect(err).To(MatchError(ContainSubstring("stdout output")))
 					Expect(err).To(MatchError(ContainSubstring("stderr output")))
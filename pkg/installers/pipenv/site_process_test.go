@@ -5,6 +5,7 @@
 package pipenv_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
@@ -26,6 +28,7 @@ func testSiteProcess(t *testing.T, context spec.G, it spec.S) {
 
 		targetLayerPath string
 		executable      *fakes.Executable
+		buffer          *bytes.Buffer
 
 		siteProcess pipenv.SiteProcess
 	)
@@ -34,6 +37,7 @@ func testSiteProcess(t *testing.T, context spec.G, it spec.S) {
 		targetLayerPath = t.TempDir()
 
 		executable = &fakes.Executable{}
+		buffer = bytes.NewBuffer(nil)
 		executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
 			if execution.Stdout != nil {
 				_, err := fmt.Fprint(execution.Stdout, targetLayerPath, "/pipenv/lib/python/site-packages")
@@ -42,7 +46,7 @@ func testSiteProcess(t *testing.T, context spec.G, it spec.S) {
 			return nil
 		}
 
-		siteProcess = pipenv.NewSiteProcess(executable)
+		siteProcess = pipenv.NewSiteProcess(executable, scribe.NewEmitter(buffer))
 	})
 
 	context("Execute", func() {
@@ -6,16 +6,14 @@
 package pixi
 
 import (
-	"path/filepath"
 	"time"
 
 	"github.com/paketo-buildpacks/packit/v2"
-	"github.com/paketo-buildpacks/packit/v2/cargo"
 	"github.com/paketo-buildpacks/packit/v2/draft"
-	"github.com/paketo-buildpacks/packit/v2/sbom"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
 )
 
 //go:generate faux --interface Runner --output fakes/runner.go
@@ -58,14 +56,12 @@ func Build(
 		entry, sortedEntries := planner.Resolve(Pixi, context.Plan.Entries, Priorities)
 		logger.Candidates(sortedEntries)
 
-		version, _ := entry.Metadata["version"].(string)
-
-		dependency, err := dependencyManager.Resolve(filepath.Join(context.CNBPath, "buildpack.toml"), entry.Name, version, context.Stack)
+		dependency, err := dependency.Resolve(dependencyManager, logger, clock, context.CNBPath, entry.Name, entry, context.Stack)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
-		logger.SelectedDependency(entry, dependency, clock.Now())
+		layerMetadata := build.NewLayerMetadata(dependency, context.BuildpackInfo.Version, nil)
 
 		legacySBOM := dependencyManager.GenerateBillOfMaterials(dependency)
 
@@ -86,19 +82,18 @@ func Build(
 			launchMetadata = packit.LaunchMetadata{BOM: legacySBOM}
 		}
 
-		cachedChecksum, ok := pixiLayer.Metadata[DepKey].(string)
-		dependencyChecksum := dependency.Checksum
-		if dependencyChecksum == "" {
-			//nolint:staticcheck // SHA256 is only a fallback in case Checksum is not present
-			dependencyChecksum = dependency.SHA256
-		}
-
-		if ok && cachedChecksum != "" && cargo.Checksum(cachedChecksum).MatchString(dependencyChecksum) {
+		reusable, reason := layerMetadata.Matches(pixiLayer.Metadata)
+		if reusable {
 			logger.Process("Reusing cached layer %s", pixiLayer.Path)
 			logger.Break()
 
 			pixiLayer.Launch, pixiLayer.Build, pixiLayer.Cache = launch, build, build
 
+			pixiLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, pixiLayer.Path, context.BuildpackInfo.SBOMFormats)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
 			return packit.BuildResult{
 				Layers: []packit.Layer{pixiLayer},
 				Build:  buildMetadata,
@@ -106,6 +101,10 @@ func Build(
 			}, nil
 		}
 
+		if reason != "" {
+			logger.Process("Rebuilding cached layer %s: %s", pixiLayer.Path, reason)
+		}
+
 		pixiLayer, err = pixiLayer.Reset()
 		if err != nil {
 			return packit.BuildResult{}, err
@@ -145,25 +144,9 @@ func Build(
 		logger.Action("Completed in %s", duration.Round(time.Millisecond))
 		logger.Break()
 
-		pixiLayer.Metadata = map[string]interface{}{
-			DepKey: dependencyChecksum,
-		}
-
-		logger.GeneratingSBOM(pixiLayer.Path)
-		var sbomContent sbom.SBOM
-		duration, err = clock.Measure(func() error {
-			sbomContent, err = sbomGenerator.GenerateFromDependency(dependency, pixiLayer.Path)
-			return err
-		})
-		if err != nil {
-			return packit.BuildResult{}, err
-		}
-
-		logger.Action("Completed in %s", duration.Round(time.Millisecond))
-		logger.Break()
+		pixiLayer.Metadata = layerMetadata.ToMap()
 
-		logger.FormattingSBOM(context.BuildpackInfo.SBOMFormats...)
-		pixiLayer.SBOM, err = sbomContent.InFormats(context.BuildpackInfo.SBOMFormats...)
+		pixiLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, pixiLayer.Path, context.BuildpackInfo.SBOMFormats)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
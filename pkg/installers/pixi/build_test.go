@@ -8,8 +8,10 @@ package pixi_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
@@ -18,6 +20,7 @@ import (
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	dependencyfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency/fakes"
 	sbomfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/sbomtest"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pixi"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pixi/fakes"
@@ -144,8 +147,14 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(layer.Launch).To(BeFalse())
 		Expect(layer.Cache).To(BeFalse())
 
-		Expect(layer.Metadata).To(HaveLen(1))
-		Expect(layer.Metadata["dependency-sha"]).To(Equal("pixi-dependency-sha"))
+		Expect(layer.Metadata).To(Equal(map[string]interface{}{
+			"schema-version":      1,
+			"buildpack-version":   "some-version",
+			"dependency-checksum": "pixi-dependency-sha",
+			"dependency-version":  "pixi-dependency-version",
+			"dependency-arch":     runtime.GOARCH,
+			"install-options":     map[string]interface{}{},
+		}))
 
 		Expect(layer.SBOM.Formats()).To(HaveLen(2))
 		var actualExtensions []string
@@ -244,6 +253,61 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("when the layer was previously built", func() {
+		it.Before(func() {
+			err := os.WriteFile(filepath.Join(layersDir, "pixi.toml"), []byte(fmt.Sprintf(`[metadata]
+			schema-version = 1
+			buildpack-version = "some-version"
+			dependency-checksum = "pixi-dependency-sha"
+			dependency-version = "pixi-dependency-version"
+			dependency-arch = "%s"
+			`, runtime.GOARCH)), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("reuses the cached layer", func() {
+			result, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(HaveLen(1))
+			Expect(buffer.String()).To(ContainSubstring("Reusing cached layer"))
+
+			sbomtest.ExpectLayerSBOM(t, result.Layers[0], sbomGenerator, filepath.Join(layersDir, "pixi"))
+			Expect(installProcess.ExecuteCall.CallCount).To(Equal(0))
+		})
+
+		context("when generating the SBOM of the cached layer returns an error", func() {
+			it.Before(func() {
+				sbomGenerator.GenerateFromDependencyCall.Returns.Error = errors.New("failed to generate SBOM")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to generate SBOM")))
+			})
+		})
+
+		context("with the legacy metadata schema", func() {
+			it.Before(func() {
+				err := os.WriteFile(filepath.Join(layersDir, "pixi.toml"), []byte(`[metadata]
+				dependency-sha = "pixi-dependency-sha"
+				`), os.ModePerm)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			it("rebuilds the layer and explains why", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring(fmt.Sprintf(
+					"Rebuilding cached layer %s: layer metadata uses the legacy schema instead of version 1",
+					filepath.Join(layersDir, "pixi"),
+				)))
+				Expect(installProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
+		})
+	})
+
 	context("failure cases", func() {
 		context("when the dependency manager resolution fails", func() {
 			it.Before(func() {
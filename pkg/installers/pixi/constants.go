@@ -13,11 +13,6 @@ const (
 	// ProjectFilename is the name of the pixi project file
 	ProjectFilename = "pixi.toml"
 
-	// This is the key name that we use to store the sha of the script we
-	// download in the layer metadata, which is used to determine if the uvs
-	// layer can be reused on during a rebuild.
-	DepKey = "dependency-sha"
-
 	PixiArchiveTemplateName = "uv-%s-unknown-linux-musl"
 
 	EnvVersion = "BP_PIXI_VERSION"
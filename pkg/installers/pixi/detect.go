@@ -11,32 +11,53 @@ import (
 
 	"github.com/paketo-buildpacks/packit/v2"
 	"github.com/paketo-buildpacks/packit/v2/fs"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pythonversion"
 )
 
 // Detect will return a packit.DetectFunc that will be invoked during the
 // detect phase of the buildpack lifecycle.
 //
-// Detection always passes, and will contribute a  Build Plan that provides uv.
-func Detect() packit.DetectFunc {
+// Detection passes when a pixi.lock or a pixi.toml is present and will
+// contribute a Build Plan that provides pixi.
+//
+// pixi manages the interpreter itself, so the python dependency of pixi.toml
+// is only logged and a warning is logged when .python-version conflicts with
+// it.
+func Detect(loader project.Loader, logger scribe.Emitter) packit.DetectFunc {
 	return func(context packit.DetectContext) (packit.DetectResult, error) {
-		lockfile := filepath.Join(context.WorkingDir, LockfileName)
+		proj, err := loader.Load(context.WorkingDir)
+		if err != nil {
+			return packit.DetectResult{}, err
+		}
+
+		lockfile := filepath.Join(proj.Path, LockfileName)
 		lockfileExists, err := fs.Exists(lockfile)
 		if err != nil {
 			return packit.DetectResult{}, err
 		}
 
-		projectFile := filepath.Join(context.WorkingDir, ProjectFilename)
-		projeectfileExists, err := fs.Exists(projectFile)
+		pixiToml, err := proj.PixiToml()
 		if err != nil {
 			return packit.DetectResult{}, err
 		}
 
-		if !lockfileExists && !projeectfileExists {
+		if !lockfileExists && pixiToml == nil {
 			return packit.DetectResult{}, packit.Fail.WithMessage("neither %s nor %s are present", LockfileName, ProjectFilename)
 		}
 
+		if pythonVersion := pixiToml.PythonVersion(); pythonVersion != "" {
+			logger.Detail("Python requirement %q from %s", pythonVersion, ProjectFilename)
+
+			pythonversion.ResolveEntry(logger, proj.PythonVersionEntry, pythonversion.Requirement{
+				Version: pythonVersion,
+				Source:  ProjectFilename,
+			})
+		}
+
 		plan := packit.BuildPlan{
 			Provides: []packit.BuildPlanProvision{
 				{Name: Pixi},
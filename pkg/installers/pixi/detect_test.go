@@ -6,15 +6,18 @@
 package pixi_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pixi"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 
 	. "github.com/onsi/gomega"
 )
@@ -23,6 +26,8 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
 
+		buffer *bytes.Buffer
+
 		workingDir string
 
 		detect packit.DetectFunc
@@ -36,7 +41,8 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		Expect(os.WriteFile(filepath.Join(workingDir, pixi.LockfileName), []byte(``), 0755)).To(Succeed())
 		Expect(os.WriteFile(filepath.Join(workingDir, pixi.ProjectFilename), []byte(``), 0755)).To(Succeed())
 
-		detect = pixi.Detect()
+		buffer = bytes.NewBuffer(nil)
+		detect = pixi.Detect(project.NewLoader(), scribe.NewEmitter(buffer))
 	})
 
 	it.After(func() {
@@ -88,6 +94,37 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("when pixi.toml depends on python", func() {
+		it.Before(func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, pixi.ProjectFilename), []byte(`[dependencies]
+python = ">=3.12,<3.14"
+`), 0755)).To(Succeed())
+		})
+
+		it("logs the python requirement", func() {
+			_, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buffer.String()).To(ContainSubstring(`Python requirement ">=3.12,<3.14" from pixi.toml`))
+			Expect(buffer.String()).NotTo(ContainSubstring("Warning"))
+		})
+
+		context("and .python-version does not satisfy it", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.11\n"), 0644)).To(Succeed())
+			})
+
+			it("warns about the conflict", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buffer.String()).To(ContainSubstring(`Warning: .python-version pins python 3.11 which does not satisfy ">=3.12,<3.14" from pixi.toml`))
+			})
+		})
+	})
+
 	context("when files are missing", func() {
 		context("when pixi.lock is not present", func() {
 			it.Before(func() {
@@ -150,6 +187,19 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 	})
 
 	context("error handling", func() {
+		context("when pixi.toml is malformed", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, pixi.ProjectFilename), []byte("%%%"), 0755)).To(Succeed())
+			})
+
+			it("returns the error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring("failed to parse pixi.toml")))
+			})
+		})
+
 		context("when there is an error determining if the pixi.lock and pixi.toml files exist", func() {
 			it.Before(func() {
 				Expect(os.Chmod(workingDir, 0000)).To(Succeed())
@@ -167,4 +217,33 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 	})
+
+	context("when BP_PYTHON_PROJECT_PATH points to a sub-directory", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+			Expect(os.Rename(filepath.Join(workingDir, pixi.LockfileName), filepath.Join(workingDir, "services", "api", pixi.LockfileName))).To(Succeed())
+			Expect(os.Rename(filepath.Join(workingDir, pixi.ProjectFilename), filepath.Join(workingDir, "services", "api", pixi.ProjectFilename))).To(Succeed())
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+		})
+
+		it("detects the project in the sub-directory", func() {
+			_, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		context("when the sub-directory does not exist", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+			})
+
+			it("returns an error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`)))
+			})
+		})
+	})
 }
@@ -6,24 +6,28 @@ package poetry
 
 import (
 	"fmt"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/paketo-buildpacks/packit/v2"
 	"github.com/paketo-buildpacks/packit/v2/draft"
-	"github.com/paketo-buildpacks/packit/v2/sbom"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/interpreter"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/virtualenv"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
 )
 
 //go:generate faux --interface InstallProcess --output fakes/install_process.go
 //go:generate faux --interface SitePackageProcess --output fakes/site_package_process.go
+//go:generate faux --interface VirtualenvInstallProcess --output fakes/virtualenv_install_process.go
+//go:generate faux --interface InterpreterProcess --output fakes/interpreter_process.go
 
 // InstallProcess defines the interface for installing the poetry dependency into a layer.
 type InstallProcess interface {
-	Execute(version, targetLayerPath, pipLayerPath string) error
+	Execute(requirements []string, targetLayerPath, pipLayerPath string) error
 }
 
 // SitePackageProcess defines the interface for looking site packages within a layer.
@@ -31,12 +35,26 @@ type SitePackageProcess interface {
 	Execute(targetLayerPath string) (string, error)
 }
 
+// VirtualenvInstallProcess defines the interface for installing the poetry
+// dependency into its own virtual environment within a layer.
+type VirtualenvInstallProcess interface {
+	Execute(targetLayerPath, pipLayerPath string, requirements []string, entrypoints ...string) error
+}
+
+// InterpreterProcess defines the interface for identifying the python
+// interpreter the layer is built with.
+type InterpreterProcess interface {
+	Execute() (string, error)
+}
+
 // PoetryBuildParameters encapsulates the pip specific parameters for the
 // Build function
 type PoetryBuildParameters struct {
-	DependencyManager  dependency.DependencyManager
-	InstallProcess     InstallProcess
-	SitePackageProcess SitePackageProcess
+	DependencyManager        dependency.DependencyManager
+	InstallProcess           InstallProcess
+	SitePackageProcess       SitePackageProcess
+	VirtualenvInstallProcess VirtualenvInstallProcess
+	InterpreterProcess       InterpreterProcess
 }
 
 func Build(
@@ -46,6 +64,8 @@ func Build(
 	return func(context packit.BuildContext) (packit.BuildResult, error) {
 		installProcess := buildParameters.InstallProcess
 		siteProcess := buildParameters.SitePackageProcess
+		virtualenvInstallProcess := buildParameters.VirtualenvInstallProcess
+		interpreterProcess := buildParameters.InterpreterProcess
 		dependencyManager := buildParameters.DependencyManager
 		sbomGenerator := parameters.SbomGenerator
 		clock := parameters.Clock
@@ -58,17 +78,26 @@ func Build(
 		entry, entries := planner.Resolve(PoetryDependency, context.Plan.Entries, Priorities)
 		logger.Candidates(entries)
 
-		version, ok := entry.Metadata["version"].(string)
-		if !ok {
-			version = "default"
+		pythonInterpreter, err := interpreterProcess.Execute()
+		if err != nil {
+			return packit.BuildResult{}, err
+		}
+
+		dependency, err := dependency.ResolveForPython(dependencyManager, logger, clock, context.CNBPath, entry.Name, entry, context.Stack, interpreter.Version(pythonInterpreter))
+		if err != nil {
+			return packit.BuildResult{}, err
 		}
 
-		dependency, err := dependencyManager.Resolve(filepath.Join(context.CNBPath, "buildpack.toml"), entry.Name, version, context.Stack)
+		installMode, err := virtualenv.GetInstallMode(EnvUserInstall)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
-		logger.SelectedDependency(entry, dependency, clock.Now())
+		layerMetadata := build.NewLayerMetadata(dependency, context.BuildpackInfo.Version, map[string]string{
+			InstallModeKey: installMode,
+			InterpreterKey: pythonInterpreter,
+		})
+
 		legacySBOM := dependencyManager.GenerateBillOfMaterials(dependency)
 
 		poetryLayer, err := context.Layers.Get(PoetryLayerName)
@@ -88,13 +117,18 @@ func Build(
 			launchMetadata = packit.LaunchMetadata{BOM: legacySBOM}
 		}
 
-		cachedChecksum, ok := poetryLayer.Metadata[DependencyChecksumKey].(string)
-		if ok && cachedChecksum == dependency.Checksum {
+		reusable, reason := layerMetadata.Matches(poetryLayer.Metadata)
+		if reusable {
 			logger.Process("Reusing cached layer %s", poetryLayer.Path)
 			logger.Break()
 
 			poetryLayer.Launch, poetryLayer.Build, poetryLayer.Cache = launch, build, build
 
+			poetryLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, poetryLayer.Path, context.BuildpackInfo.SBOMFormats)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
 			return packit.BuildResult{
 				Layers: []packit.Layer{poetryLayer},
 				Build:  buildMetadata,
@@ -102,6 +136,10 @@ func Build(
 			}, nil
 		}
 
+		if reason != "" {
+			logger.Process("Rebuilding cached layer %s: %s", poetryLayer.Path, reason)
+		}
+
 		poetryLayer, err = poetryLayer.Reset()
 		if err != nil {
 			return packit.BuildResult{}, err
@@ -110,14 +148,23 @@ func Build(
 		poetryLayer.Launch, poetryLayer.Build, poetryLayer.Cache = launch, build, build
 
 		logger.Process("Executing build process")
-		logger.Subprocess("Installing Poetry %s", dependency.Version)
+		logger.Subprocess("Installing Poetry %s (%s install)", dependency.Version, installMode)
 		pipLayer, err := context.Layers.Get(Pip)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
 		duration, err := clock.Measure(func() error {
-			err = installProcess.Execute(dependency.Version, poetryLayer.Path, pipLayer.Path)
+			requirements, err := wheelhouse.Requirements(context, dependencyManager, logger, dependency.ID, dependency.Version, interpreter.ABI(pythonInterpreter), Requirement(dependency.Version))
+			if err != nil {
+				return err
+			}
+
+			if installMode == virtualenv.ModeVirtualenv {
+				return virtualenvInstallProcess.Execute(poetryLayer.Path, pipLayer.Path, requirements, PoetryDependency)
+			}
+
+			err = installProcess.Execute(requirements, poetryLayer.Path, pipLayer.Path)
 			if err != nil {
 				return err
 			}
@@ -143,30 +190,14 @@ func Build(
 		logger.Action("Completed in %s", duration.Round(time.Millisecond))
 		logger.Break()
 
-		logger.GeneratingSBOM(poetryLayer.Path)
-		var sbomContent sbom.SBOM
-		duration, err = clock.Measure(func() error {
-			sbomContent, err = sbomGenerator.GenerateFromDependency(dependency, poetryLayer.Path)
-			return err
-		})
-		if err != nil {
-			return packit.BuildResult{}, err
-		}
-
-		logger.Action("Completed in %s", duration.Round(time.Millisecond))
-		logger.Break()
-
-		logger.FormattingSBOM(context.BuildpackInfo.SBOMFormats...)
-		poetryLayer.SBOM, err = sbomContent.InFormats(context.BuildpackInfo.SBOMFormats...)
+		poetryLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, poetryLayer.Path, context.BuildpackInfo.SBOMFormats)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
 		logger.EnvironmentVariables(poetryLayer)
 
-		poetryLayer.Metadata = map[string]interface{}{
-			DependencyChecksumKey: dependency.Checksum,
-		}
+		poetryLayer.Metadata = layerMetadata.ToMap()
 
 		return packit.BuildResult{
 			Layers: []packit.Layer{poetryLayer},
@@ -7,8 +7,10 @@ package poetry_test
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
@@ -24,6 +26,8 @@ import (
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	dependencyfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency/fakes"
 	sbomfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/sbomtest"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry/fakes"
@@ -39,10 +43,15 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		cnbDir    string
 
 		dependencyManager *dependencyfakes.DependencyManager
+		resolvedVersions  map[string]string
+		wheelhouses       map[string]postal.Dependency
 		installProcess    *fakes.InstallProcess
 		siteProcess       *fakes.SitePackageProcess
 		sbomGenerator     *sbomfakes.SBOMGenerator
 
+		virtualenvInstallProcess *fakes.VirtualenvInstallProcess
+		interpreterProcess       *fakes.InterpreterProcess
+
 		buffer *bytes.Buffer
 
 		buildFunc    packit.BuildFunc
@@ -68,6 +77,22 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			Version:  "poetry-dependency-version",
 		}
 
+		// buildpack.toml has no wheelhouse unless a test adds one
+		resolvedVersions = map[string]string{}
+		wheelhouses = map[string]postal.Dependency{}
+		dependencyManager.ResolveCall.Stub = func(path, id, version, stack string) (postal.Dependency, error) {
+			resolvedVersions[id] = version
+			if id == "poetry" {
+				return dependencyManager.ResolveCall.Returns.Dependency, dependencyManager.ResolveCall.Returns.Error
+			}
+
+			wheelhouse, ok := wheelhouses[id]
+			if !ok {
+				return postal.Dependency{}, &postal.ErrNoDeps{}
+			}
+			return wheelhouse, nil
+		}
+
 		dependencyManager.GenerateBillOfMaterialsCall.Returns.BOMEntrySlice = []packit.BOMEntry{
 			{
 				Name: "poetry",
@@ -89,6 +114,9 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		installProcess = &fakes.InstallProcess{}
 		siteProcess = &fakes.SitePackageProcess{}
 		siteProcess.ExecuteCall.Returns.String = filepath.Join(layersDir, "poetry", "lib", "python3.8", "site-packages")
+		virtualenvInstallProcess = &fakes.VirtualenvInstallProcess{}
+		interpreterProcess = &fakes.InterpreterProcess{}
+		interpreterProcess.ExecuteCall.Returns.String = "3.8-cpython-38-x86_64-linux-gnu"
 
 		buffer = bytes.NewBuffer(nil)
 		logger := scribe.NewEmitter(buffer)
@@ -98,6 +126,8 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 				dependencyManager,
 				installProcess,
 				siteProcess,
+				virtualenvInstallProcess,
+				interpreterProcess,
 			},
 			build.CommonBuildParameters{
 				SbomGenerator: sbomGenerator,
@@ -139,10 +169,7 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(layer.Name).To(Equal("poetry"))
 		Expect(layer.Path).To(Equal(filepath.Join(layersDir, "poetry")))
 
-		Expect(layer.SharedEnv).To(HaveLen(2))
-		Expect(layer.SharedEnv["PYTHONPATH.delim"]).To(Equal(":"))
-		Expect(layer.SharedEnv["PYTHONPATH.prepend"]).To(Equal(filepath.Join(layersDir, "poetry", "lib/python3.8/site-packages")))
-
+		Expect(layer.SharedEnv).To(BeEmpty())
 		Expect(layer.BuildEnv).To(BeEmpty())
 		Expect(layer.LaunchEnv).To(BeEmpty())
 		Expect(layer.ProcessLaunchEnv).To(BeEmpty())
@@ -151,8 +178,17 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(layer.Launch).To(BeFalse())
 		Expect(layer.Cache).To(BeFalse())
 
-		Expect(layer.Metadata).To(HaveLen(1))
-		Expect(layer.Metadata["dependency-checksum"]).To(Equal("poetry-dependency-sha"))
+		Expect(layer.Metadata).To(Equal(map[string]interface{}{
+			"schema-version":      1,
+			"buildpack-version":   "some-version",
+			"dependency-checksum": "poetry-dependency-sha",
+			"dependency-version":  "poetry-dependency-version",
+			"dependency-arch":     runtime.GOARCH,
+			"install-options": map[string]interface{}{
+				"install-mode": "virtualenv",
+				"interpreter":  "3.8-cpython-38-x86_64-linux-gnu",
+			},
+		}))
 
 		Expect(layer.SBOM.Formats()).To(HaveLen(2))
 		var actualExtensions []string
@@ -162,8 +198,10 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		Expect(actualExtensions).To(ConsistOf("cdx.json", "spdx.json"))
 
 		Expect(dependencyManager.ResolveCall.Receives.Path).To(Equal(filepath.Join(cnbDir, "buildpack.toml")))
-		Expect(dependencyManager.ResolveCall.Receives.Id).To(Equal("poetry"))
-		Expect(dependencyManager.ResolveCall.Receives.Version).To(Equal("default"))
+		Expect(resolvedVersions).To(Equal(map[string]string{
+			"poetry":      "",
+			"poetry-cp38": "poetry-dependency-version",
+		}))
 		Expect(dependencyManager.ResolveCall.Receives.Stack).To(Equal("some-stack"))
 
 		Expect(dependencyManager.GenerateBillOfMaterialsCall.CallCount).To(Equal(1))
@@ -180,17 +218,178 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 
 		Expect(sbomGenerator.GenerateFromDependencyCall.Receives.Dir).To(Equal(filepath.Join(layersDir, "poetry")))
 
-		Expect(installProcess.ExecuteCall.Receives.Version).To(ContainSubstring("poetry-dependency-version"))
-		Expect(installProcess.ExecuteCall.Receives.TargetLayerPath).To(Equal(filepath.Join(layersDir, "poetry")))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.TargetLayerPath).To(Equal(filepath.Join(layersDir, "poetry")))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.PipLayerPath).To(Equal(filepath.Join(layersDir, "pip")))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.Requirements).To(Equal([]string{"poetry==poetry-dependency-version"}))
+		Expect(virtualenvInstallProcess.ExecuteCall.Receives.Entrypoints).To(Equal([]string{"poetry"}))
+
+		Expect(installProcess.ExecuteCall.CallCount).To(Equal(0))
+		Expect(siteProcess.ExecuteCall.CallCount).To(Equal(0))
 
 		Expect(buffer.String()).To(ContainSubstring("Some Buildpack some-version"))
 		Expect(buffer.String()).To(ContainSubstring("Resolving Poetry version"))
-		Expect(buffer.String()).To(ContainSubstring("Selected poetry-dependency-name version (using <unknown>): poetry-dependency-version"))
+		Expect(buffer.String()).To(ContainSubstring("Selected poetry-dependency-name version (using latest available): poetry-dependency-version"))
 		Expect(buffer.String()).To(ContainSubstring("Executing build process"))
-		Expect(buffer.String()).To(ContainSubstring("Installing Poetry poetry-dependency-version"))
+		Expect(buffer.String()).To(ContainSubstring("Installing Poetry poetry-dependency-version (virtualenv install)"))
+		Expect(buffer.String()).To(ContainSubstring("No wheelhouse of poetry poetry-dependency-version for the python interpreter, installing from PyPI"))
 		Expect(buffer.String()).To(ContainSubstring("Completed in"))
 	})
 
+	context("when BP_POETRY_USER_INSTALL is true", func() {
+		it.Before(func() {
+			t.Setenv("BP_POETRY_USER_INSTALL", "true")
+		})
+
+		it("installs poetry in the user site packages of the layer", func() {
+			result, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(HaveLen(1))
+			layer := result.Layers[0]
+
+			Expect(layer.SharedEnv).To(HaveLen(2))
+			Expect(layer.SharedEnv["PYTHONPATH.delim"]).To(Equal(":"))
+			Expect(layer.SharedEnv["PYTHONPATH.prepend"]).To(Equal(filepath.Join(layersDir, "poetry", "lib/python3.8/site-packages")))
+
+			Expect(layer.Metadata["install-options"]).To(Equal(map[string]interface{}{
+				"install-mode": "user",
+				"interpreter":  "3.8-cpython-38-x86_64-linux-gnu",
+			}))
+
+			Expect(installProcess.ExecuteCall.Receives.Requirements).To(Equal([]string{"poetry==poetry-dependency-version"}))
+			Expect(installProcess.ExecuteCall.Receives.TargetLayerPath).To(Equal(filepath.Join(layersDir, "poetry")))
+
+			Expect(virtualenvInstallProcess.ExecuteCall.CallCount).To(Equal(0))
+
+			Expect(buffer.String()).To(ContainSubstring("Installing Poetry poetry-dependency-version (user install)"))
+		})
+	})
+
+	context("when buildpack.toml has a wheelhouse for the python interpreter", func() {
+		it.Before(func() {
+			wheelhouses["poetry-cp38"] = postal.Dependency{
+				ID:      "poetry-cp38",
+				Version: "poetry-dependency-version",
+			}
+		})
+
+		it("installs poetry from the wheelhouse", func() {
+			_, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(dependencyManager.DeliverCall.Receives.Dependency.ID).To(Equal("poetry-cp38"))
+			Expect(dependencyManager.DeliverCall.Receives.DestinationPath).To(Equal(filepath.Join(layersDir, "poetry-wheelhouse")))
+
+			Expect(virtualenvInstallProcess.ExecuteCall.Receives.Requirements).To(Equal(wheelhouse.InstallArgs(filepath.Join(layersDir, "poetry-wheelhouse"))))
+
+			Expect(buffer.String()).To(ContainSubstring("Installing from the wheelhouse poetry-cp38"))
+		})
+
+		context("when BP_POETRY_USER_INSTALL is true", func() {
+			it.Before(func() {
+				t.Setenv("BP_POETRY_USER_INSTALL", "true")
+			})
+
+			it("installs poetry from the wheelhouse in the user site packages of the layer", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(installProcess.ExecuteCall.Receives.Requirements).To(Equal(wheelhouse.InstallArgs(filepath.Join(layersDir, "poetry-wheelhouse"))))
+			})
+		})
+
+		context("when the wheelhouse cannot be delivered", func() {
+			it.Before(func() {
+				dependencyManager.DeliverCall.Returns.Error = errors.New("failed to deliver the wheelhouse")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError("failed to deliver the wheelhouse"))
+			})
+		})
+	})
+
+	context("when the layer was previously built", func() {
+		it.Before(func() {
+			err := os.WriteFile(filepath.Join(layersDir, "poetry.toml"), []byte(fmt.Sprintf(`[metadata]
+			schema-version = 1
+			buildpack-version = "some-version"
+			dependency-checksum = "poetry-dependency-sha"
+			dependency-version = "poetry-dependency-version"
+			dependency-arch = "%s"
+			[metadata.install-options]
+			install-mode = "virtualenv"
+			interpreter = "3.8-cpython-38-x86_64-linux-gnu"
+			`, runtime.GOARCH)), os.ModePerm)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("reuses the cached layer", func() {
+			result, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.Layers).To(HaveLen(1))
+			Expect(result.Layers[0].Name).To(Equal("poetry"))
+
+			Expect(buffer.String()).To(ContainSubstring("Reusing cached layer"))
+
+			sbomtest.ExpectLayerSBOM(t, result.Layers[0], sbomGenerator, filepath.Join(layersDir, "poetry"))
+			Expect(virtualenvInstallProcess.ExecuteCall.CallCount).To(Equal(0))
+			Expect(installProcess.ExecuteCall.CallCount).To(Equal(0))
+		})
+
+		context("when generating the SBOM of the cached layer returns an error", func() {
+			it.Before(func() {
+				sbomGenerator.GenerateFromDependencyCall.Returns.Error = errors.New("failed to generate SBOM")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to generate SBOM")))
+			})
+		})
+
+		context("with a different install mode", func() {
+			it.Before(func() {
+				t.Setenv("BP_POETRY_USER_INSTALL", "true")
+			})
+
+			it("reinstalls poetry", func() {
+				result, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.Layers).To(HaveLen(1))
+				Expect(result.Layers[0].Metadata["install-options"]).To(HaveKeyWithValue("install-mode", "user"))
+
+				Expect(buffer.String()).To(ContainSubstring(`install-mode changed from "virtualenv" to "user"`))
+				Expect(buffer.String()).NotTo(ContainSubstring("Reusing cached layer"))
+				Expect(installProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
+		})
+
+		context("with a different python interpreter", func() {
+			it.Before(func() {
+				interpreterProcess.ExecuteCall.Returns.String = "3.9-cpython-39-x86_64-linux-gnu"
+			})
+
+			it("reinstalls poetry and explains why", func() {
+				result, err := buildFunc(buildContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(result.Layers).To(HaveLen(1))
+				Expect(result.Layers[0].Metadata["install-options"]).To(HaveKeyWithValue("interpreter", "3.9-cpython-39-x86_64-linux-gnu"))
+
+				Expect(buffer.String()).To(ContainSubstring(fmt.Sprintf(
+					`Rebuilding cached layer %s: interpreter changed from "3.8-cpython-38-x86_64-linux-gnu" to "3.9-cpython-39-x86_64-linux-gnu"`,
+					filepath.Join(layersDir, "poetry"),
+				)))
+				Expect(buffer.String()).NotTo(ContainSubstring("Reusing cached layer"))
+				Expect(virtualenvInstallProcess.ExecuteCall.CallCount).To(Equal(1))
+			})
+		})
+	})
+
 	context("when the plan entry requires the dependency during the build and launch phases", func() {
 		it.Before(func() {
 			buildContext.Plan.Entries[0].Metadata = map[string]interface{}{
@@ -246,7 +445,42 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 		})
 	})
 
+	context("when some poetry versions do not support the python interpreter", func() {
+		it.Before(func() {
+			Expect(os.WriteFile(filepath.Join(cnbDir, "buildpack.toml"), []byte(`[[metadata.dependencies]]
+  id = "poetry"
+  version = "1.8.5"
+  requires-python = ">=3.8,<4.0"
+
+[[metadata.dependencies]]
+  id = "poetry"
+  version = "2.3.2"
+  requires-python = ">=3.10,<4.0"
+`), 0644)).To(Succeed())
+		})
+
+		it("excludes them from the resolution", func() {
+			_, err := buildFunc(buildContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resolvedVersions["poetry"]).To(Equal("*, !=2.3.2"))
+			Expect(buffer.String()).To(ContainSubstring("Skipping the poetry versions not supporting python 3.8:"))
+			Expect(buffer.String()).To(ContainSubstring("2.3.2 requires python >=3.10,<4.0"))
+		})
+	})
+
 	context("failure cases", func() {
+		context("when the python interpreter cannot be identified", func() {
+			it.Before(func() {
+				interpreterProcess.ExecuteCall.Returns.Error = errors.New("failed to identify python interpreter")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("failed to identify python interpreter")))
+			})
+		})
+
 		context("when the dependency cannot be resolved", func() {
 			it.Before(func() {
 				dependencyManager.ResolveCall.Returns.Error = errors.New("failed to resolve dependency")
@@ -286,8 +520,31 @@ func testBuild(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("when BP_POETRY_USER_INSTALL is invalid", func() {
+			it.Before(func() {
+				t.Setenv("BP_POETRY_USER_INSTALL", "not-a-bool")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError(ContainSubstring("invalid value for BP_POETRY_USER_INSTALL")))
+			})
+		})
+
+		context("when the virtualenv install process returns an error", func() {
+			it.Before(func() {
+				virtualenvInstallProcess.ExecuteCall.Returns.Error = errors.New("failed to run virtualenv install process")
+			})
+
+			it("returns an error", func() {
+				_, err := buildFunc(buildContext)
+				Expect(err).To(MatchError("failed to run virtualenv install process"))
+			})
+		})
+
 		context("when the install process returns an error", func() {
 			it.Before(func() {
+				t.Setenv("BP_POETRY_USER_INSTALL", "true")
 				installProcess.ExecuteCall.Returns.Error = errors.New("failed to run install process")
 			})
 
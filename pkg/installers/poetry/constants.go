@@ -5,12 +5,27 @@
 package poetry
 
 const (
-	DependencyChecksumKey = "dependency-checksum"
-	PoetryDependency      = "poetry"
-	PoetryLayerName       = "poetry"
-	CPython               = "cpython"
-	Pip                   = "pip"
-	EnvVersion            = "BP_POETRY_VERSION"
+	PoetryDependency = "poetry"
+	PoetryLayerName  = "poetry"
+	CPython          = "cpython"
+	Pip              = "pip"
+	EnvVersion       = "BP_POETRY_VERSION"
+
+	// LockfileName is the name of the poetry lock file
+	LockfileName = "poetry.lock"
+
+	// EnvUserInstall selects the legacy `pip install --user` installation
+	// instead of an isolated virtual environment.
+	EnvUserInstall = "BP_POETRY_USER_INSTALL"
+
+	// InstallModeKey is the name of the install option in the poetry layer
+	// metadata whose value is the install mode used.
+	InstallModeKey = "install-mode"
+
+	// InterpreterKey is the name of the install option in the poetry layer
+	// metadata whose value identifies the python interpreter the layer was
+	// built with.
+	InterpreterKey = "interpreter"
 )
 
 var Priorities = []interface{}{
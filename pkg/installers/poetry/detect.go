@@ -6,62 +6,75 @@ package poetry
 
 import (
 	"os"
-	"path/filepath"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pip"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pythonversion"
 
 	"github.com/paketo-buildpacks/packit/v2"
-	"github.com/paketo-buildpacks/packit/v2/fs"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 )
 
-//go:generate faux --interface PyProjectParser --output fakes/pyproject_parser.go
-type PyProjectParser interface {
-	// ParsePythonVersion extracts `tool.poetry.dependencies.python`
-	// from pyproject.toml
-	ParsePythonVersion(string) (string, error)
-	// IsPoetryProject determines whether the pyproject.toml is
-	// configured for poetry
-	IsPoetryProject(string) (bool, error)
-}
-
 const PyProjectTomlFile = "pyproject.toml"
 
-func Detect(parser PyProjectParser) packit.DetectFunc {
-	return func(context packit.DetectContext) (packit.DetectResult, error) {
-		pyProjectToml := filepath.Join(context.WorkingDir, PyProjectTomlFile)
+// PoetryBuildBackend is the build backend of poetry projects.
+const PoetryBuildBackend = "poetry.core.masonry.api"
+
+// IsPoetryProject determines whether the pyproject.toml is configured for
+// poetry, that is it either uses the poetry build backend or none at all.
+func IsPoetryProject(pyProject *project.PyProject) bool {
+	backend := pyProject.BuildBackend()
+	return backend == "" || backend == PoetryBuildBackend
+}
 
-		if exists, err := fs.Exists(pyProjectToml); err != nil {
+// Detect will return a packit.DetectFunc that will be invoked during the
+// detect phase of the buildpack lifecycle.
+//
+// Detection passes for poetry projects declaring the python version they
+// require. A version pinned in .python-version takes precedence over the
+// declared one when it satisfies it.
+func Detect(loader project.Loader, logger scribe.Emitter) packit.DetectFunc {
+	return func(context packit.DetectContext) (packit.DetectResult, error) {
+		proj, err := loader.Load(context.WorkingDir)
+		if err != nil {
 			return packit.DetectResult{}, err
-		} else if !exists {
-			return packit.DetectResult{}, packit.Fail.WithMessage("%s is not present", PyProjectTomlFile)
 		}
 
-		isPoetryProject, err := parser.IsPoetryProject(pyProjectToml)
+		pyProject, err := proj.PyProject()
 		if err != nil {
 			return packit.DetectResult{}, err
 		}
 
-		if !isPoetryProject {
+		if pyProject == nil {
+			return packit.DetectResult{}, packit.Fail.WithMessage("%s is not present", PyProjectTomlFile)
+		}
+
+		if !IsPoetryProject(pyProject) {
 			return packit.DetectResult{}, packit.Fail.WithMessage("this is not a poetry project")
 		}
 
-		pythonVersion, err := parser.ParsePythonVersion(pyProjectToml)
-		if err != nil {
-			return packit.DetectResult{}, err
+		pythonVersion := pyProject.RequiresPython()
+		if pythonVersion == "" {
+			pythonVersion = pyProject.PoetryPython()
 		}
 
 		if pythonVersion == "" {
 			return packit.DetectResult{}, packit.Fail.WithMessage("%s must include [tool.poetry.dependencies.python], see https://python-poetry.org/docs/pyproject/#dependencies-and-dev-dependencies", PyProjectTomlFile)
 		}
 
+		python := pythonversion.ResolveEntry(logger, proj.PythonVersionEntry, pythonversion.Requirement{
+			Version: pythonVersion,
+			Source:  PyProjectTomlFile,
+		})
+
 		requirements := []packit.BuildPlanRequirement{
 			{
 				Name: CPython,
 				Metadata: build.BuildPlanMetadata{
 					Build:         true,
-					Version:       pythonVersion,
-					VersionSource: PyProjectTomlFile,
+					Version:       python.Version,
+					VersionSource: python.Source,
 				},
 			},
 		}
@@ -5,17 +5,18 @@
 package poetry_test
 
 import (
-	"errors"
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry"
-	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 )
@@ -24,7 +25,7 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
 
-		parsePythonVersion *fakes.PoetryPyProjectParser
+		buffer *bytes.Buffer
 
 		workingDir string
 
@@ -36,13 +37,12 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 		workingDir, err = os.MkdirTemp("", "working-dir")
 		Expect(err).NotTo(HaveOccurred())
 
-		parsePythonVersion = &fakes.PoetryPyProjectParser{}
-		parsePythonVersion.ParsePythonVersionCall.Returns.String = "1.2.3"
-		parsePythonVersion.IsPoetryProjectCall.Returns.Bool = true
+		Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`[tool.poetry.dependencies]
+python = "1.2.3"
+`), 0755)).To(Succeed())
 
-		Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(""), 0755)).To(Succeed())
-
-		detect = poetry.Detect(parsePythonVersion)
+		buffer = bytes.NewBuffer(nil)
+		detect = poetry.Detect(project.NewLoader(), scribe.NewEmitter(buffer))
 	})
 
 	it.After(func() {
@@ -91,7 +91,6 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 				WorkingDir: workingDir,
 			})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(parsePythonVersion.ParsePythonVersionCall.Receives.String).To(Equal(filepath.Join(workingDir, "pyproject.toml")))
 			Expect(result).To(Equal(packit.DetectResult{
 				Plan: packit.BuildPlan{
 					Provides: []packit.BuildPlanProvision{
@@ -140,7 +139,9 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 
 		context("when pyproject.toml is not for poetry", func() {
 			it.Before(func() {
-				parsePythonVersion.IsPoetryProjectCall.Returns.Bool = false
+				Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`[build-system]
+build-backend = "hatchling.build"
+`), 0644)).To(Succeed())
 			})
 
 			it("fails detection", func() {
@@ -151,9 +152,11 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
-		context("when no python version is returned from the parser", func() {
+		context("when pyproject.toml declares no python version", func() {
 			it.Before(func() {
-				parsePythonVersion.ParsePythonVersionCall.Returns.String = ""
+				Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`[tool.poetry]
+name = "app"
+`), 0644)).To(Succeed())
 			})
 
 			it("fails detection", func() {
@@ -182,17 +185,95 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 				})
 			})
 
-			context("when the pyproject parser returns an error", func() {
+			context("when pyproject.toml is not valid toml", func() {
 				it.Before(func() {
-					parsePythonVersion.ParsePythonVersionCall.Returns.Error = errors.New("some-error")
+					Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte("%%%"), 0644)).To(Succeed())
 				})
 
 				it("returns the error", func() {
 					_, err := detect(packit.DetectContext{
 						WorkingDir: workingDir,
 					})
-					Expect(err).To(Equal(errors.New("some-error")))
+					Expect(err).To(MatchError(ContainSubstring("failed to parse pyproject.toml")))
+				})
+			})
+		})
+	})
+
+	context("with .python-version", func() {
+		it.Before(func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.12\n"), 0644)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`[project]
+requires-python = ">=3.10"
+`), 0644)).To(Succeed())
+		})
+
+		it("prefers the pinned version when it satisfies pyproject.toml", func() {
+			result, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Plan.Requires[0]).To(Equal(packit.BuildPlanRequirement{
+				Name: poetry.CPython,
+				Metadata: build.BuildPlanMetadata{
+					Build:         true,
+					Version:       "3.12",
+					VersionSource: ".python-version",
+				},
+			}))
+		})
+
+		context("when it conflicts with pyproject.toml", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`[tool.poetry.dependencies]
+python = "^3.13"
+`), 0644)).To(Succeed())
+			})
+
+			it("keeps the pyproject.toml version and warns", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan.Requires[0].Metadata).To(Equal(build.BuildPlanMetadata{
+					Build:         true,
+					Version:       "^3.13",
+					VersionSource: "pyproject.toml",
+				}))
+				Expect(buffer.String()).To(ContainSubstring(`Warning: .python-version pins python 3.12 which does not satisfy "^3.13" from pyproject.toml`))
+			})
+		})
+	})
+
+	context("when BP_PYTHON_PROJECT_PATH points to a sub-directory", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+			Expect(os.Rename(filepath.Join(workingDir, "pyproject.toml"), filepath.Join(workingDir, "services", "api", "pyproject.toml"))).To(Succeed())
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+		})
+
+		it("detects the project in the sub-directory", func() {
+			result, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Plan.Requires[0].Metadata).To(Equal(build.BuildPlanMetadata{
+				Build:         true,
+				Version:       "1.2.3",
+				VersionSource: "pyproject.toml",
+			}))
+		})
+
+		context("when the sub-directory does not exist", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+			})
+
+			it("returns an error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
 				})
+				Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`)))
 			})
 		})
 	})
@@ -11,22 +11,22 @@ type InstallProcess struct {
 		mutex     sync.Mutex
 		CallCount int
 		Receives  struct {
-			Version         string
+			Requirements    []string
 			TargetLayerPath string
 			PipLayerPath    string
 		}
 		Returns struct {
 			Error error
 		}
-		Stub func(string, string, string) error
+		Stub func([]string, string, string) error
 	}
 }
 
-func (f *InstallProcess) Execute(param1 string, param2 string, param3 string) error {
+func (f *InstallProcess) Execute(param1 []string, param2 string, param3 string) error {
 	f.ExecuteCall.mutex.Lock()
 	defer f.ExecuteCall.mutex.Unlock()
 	f.ExecuteCall.CallCount++
-	f.ExecuteCall.Receives.Version = param1
+	f.ExecuteCall.Receives.Requirements = param1
 	f.ExecuteCall.Receives.TargetLayerPath = param2
 	f.ExecuteCall.Receives.PipLayerPath = param3
 	if f.ExecuteCall.Stub != nil {
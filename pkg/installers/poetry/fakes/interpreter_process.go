@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package fakes
+
+import "sync"
+
+type InterpreterProcess struct {
+	ExecuteCall struct {
+		mutex     sync.Mutex
+		CallCount int
+		Returns   struct {
+			String string
+			Error  error
+		}
+		Stub func() (string, error)
+	}
+}
+
+func (f *InterpreterProcess) Execute() (string, error) {
+	f.ExecuteCall.mutex.Lock()
+	defer f.ExecuteCall.mutex.Unlock()
+	f.ExecuteCall.CallCount++
+	if f.ExecuteCall.Stub != nil {
+		return f.ExecuteCall.Stub()
+	}
+	return f.ExecuteCall.Returns.String, f.ExecuteCall.Returns.Error
+}
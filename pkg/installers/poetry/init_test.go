@@ -13,10 +13,9 @@ import (
 
 func TestUnit(t *testing.T) {
 	suite := spec.New("poetry", spec.Report(report.Terminal{}), spec.Parallel())
-	suite("Build", testBuild)
+	suite("Build", testBuild, spec.Sequential())
 	suite("Detect", testDetect, spec.Sequential())
 	suite("InstallProcess", testPoetryInstallProcess)
 	suite("SiteProcess", testSiteProcess)
-	suite("PyProjectParse", testPyProjectParser)
 	suite.Run(t)
 }
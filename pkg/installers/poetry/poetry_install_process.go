@@ -5,39 +5,49 @@
 package poetry
 
 import (
-	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pep440"
 )
 
 type PoetryInstallProcess struct {
 	executable executable.Executable
+	logger     scribe.Emitter
 }
 
 // NewPoetryInstallProcess creates a PoetryInstallProcess instance.
-func NewPoetryInstallProcess(executable executable.Executable) PoetryInstallProcess {
+func NewPoetryInstallProcess(executable executable.Executable, logger scribe.Emitter) PoetryInstallProcess {
 	return PoetryInstallProcess{
 		executable: executable,
+		logger:     logger,
 	}
 }
 
-// Execute installs the provided version of pipenv from the internet into the
-// layer path designated by targetLayerPath
-func (p PoetryInstallProcess) Execute(version, targetLayerPath, pipLayerPath string) error {
-	buffer := bytes.NewBuffer(nil)
+// Requirement returns the pip requirement pinning version of poetry, the
+// version of buildpack.toml being converted back to PEP 440.
+func Requirement(version string) string {
+	return fmt.Sprintf("poetry==%s", pep440.FromSemver(version))
+}
+
+// Execute installs the requirements of poetry into the layer path designated
+// by targetLayerPath. The requirements are passed as is to pip install and
+// either pin the version of poetry to fetch from PyPI or point to a wheelhouse.
+func (p PoetryInstallProcess) Execute(requirements []string, targetLayerPath, pipLayerPath string) error {
+	output, buffer := executable.NewOutputWriter(p.logger)
 
 	pipPath := fmt.Sprintf("PYTHONPATH=%s", filepath.Join(pipLayerPath))
 	err := p.executable.Execute(pexec.Execution{
-		Args: []string{"-m", "pip", "install", fmt.Sprintf("poetry==%s", version), "--user"},
+		Args: append(append([]string{"-m", "pip", "install"}, requirements...), "--user"),
 		// Set the PYTHONUSERBASE to ensure that poetry is installed to the newly created target layer.
 		Env:    append(os.Environ(), pipPath, fmt.Sprintf("PYTHONUSERBASE=%s", targetLayerPath)),
-		Stdout: buffer,
-		Stderr: buffer,
+		Stdout: output,
+		Stderr: output,
 	})
 
 	if err != nil {
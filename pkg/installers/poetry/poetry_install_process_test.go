@@ -5,12 +5,14 @@
 package poetry_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
@@ -27,6 +29,7 @@ func testPoetryInstallProcess(t *testing.T, context spec.G, it spec.S) {
 		destLayerPath string
 		pipLayerPath  string
 		executable    *fakes.Executable
+		buffer        *bytes.Buffer
 
 		poetryInstallProcess poetry.PoetryInstallProcess
 	)
@@ -41,14 +44,15 @@ func testPoetryInstallProcess(t *testing.T, context spec.G, it spec.S) {
 		version = "1.2.3-some.version"
 
 		executable = &fakes.Executable{}
+		buffer = bytes.NewBuffer(nil)
 
-		poetryInstallProcess = poetry.NewPoetryInstallProcess(executable)
+		poetryInstallProcess = poetry.NewPoetryInstallProcess(executable, scribe.NewEmitter(buffer))
 	})
 
 	context("Execute", func() {
 		context("there is a poetry dependency to install", func() {
 			it("installs it to the poetry layer", func() {
-				err := poetryInstallProcess.Execute(version, destLayerPath, pipLayerPath)
+				err := poetryInstallProcess.Execute([]string{poetry.Requirement(version)}, destLayerPath, pipLayerPath)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(executable.ExecuteCall.Receives.Execution.Env).To(Equal(append(os.Environ(),
@@ -59,6 +63,45 @@ func testPoetryInstallProcess(t *testing.T, context spec.G, it spec.S) {
 			})
 		})
 
+		context("when the version is a post release", func() {
+			it("pins the PEP 440 version", func() {
+				err := poetryInstallProcess.Execute([]string{poetry.Requirement("2024.0.1+post1")}, destLayerPath, pipLayerPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executable.ExecuteCall.Receives.Execution.Args).To(Equal([]string{"-m", "pip", "install", "poetry==2024.0.1.post1", "--user"}))
+			})
+		})
+
+		context("when installing from a wheelhouse", func() {
+			it("passes the wheelhouse options to pip", func() {
+				err := poetryInstallProcess.Execute([]string{"--no-index", "--find-links", "some-dir"}, destLayerPath, pipLayerPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executable.ExecuteCall.Receives.Execution.Args).To(Equal([]string{"-m", "pip", "install", "--no-index", "--find-links", "some-dir", "--user"}))
+			})
+		})
+
+		context("when the log level is debug", func() {
+			it.Before(func() {
+				poetryInstallProcess = poetry.NewPoetryInstallProcess(executable, scribe.NewEmitter(buffer).WithLevel("DEBUG"))
+				executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+					_, err := fmt.Fprintln(execution.Stdout, "stdout output")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = fmt.Fprintln(execution.Stderr, "stderr output")
+					Expect(err).NotTo(HaveOccurred())
+					return nil
+				}
+			})
+
+			it("streams the process output", func() {
+				err := poetryInstallProcess.Execute([]string{poetry.Requirement(version)}, destLayerPath, pipLayerPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring("      stdout output\n"))
+				Expect(buffer.String()).To(ContainSubstring("      stderr output\n"))
+			})
+		})
+
 		context("failure cases", func() {
 			context("the install process fails", func() {
 				it.Before(func() {
@@ -72,7 +115,7 @@ func testPoetryInstallProcess(t *testing.T, context spec.G, it spec.S) {
 				})
 
 				it("returns an error", func() {
-					err := poetryInstallProcess.Execute(version, destLayerPath, pipLayerPath)
+					err := poetryInstallProcess.Execute([]string{poetry.Requirement(version)}, destLayerPath, pipLayerPath)
 					Expect(err).To(MatchError(ContainSubstring("installing poetry failed")))
 					Expect(err).To(MatchError(ContainSubstring("stdout output")))
 					Expect(err).To(MatchError(ContainSubstring("stderr output")))
@@ -6,16 +6,14 @@
 package uv
 
 import (
-	"path/filepath"
 	"time"
 
 	"github.com/paketo-buildpacks/packit/v2"
-	"github.com/paketo-buildpacks/packit/v2/cargo"
 	"github.com/paketo-buildpacks/packit/v2/draft"
-	"github.com/paketo-buildpacks/packit/v2/sbom"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
 )
 
 //go:generate faux --interface Runner --output fakes/runner.go
@@ -58,14 +56,12 @@ func Build(
 		entry, sortedEntries := planner.Resolve(Uv, context.Plan.Entries, Priorities)
 		logger.Candidates(sortedEntries)
 
-		version, _ := entry.Metadata["version"].(string)
-
-		dependency, err := dependencyManager.Resolve(filepath.Join(context.CNBPath, "buildpack.toml"), entry.Name, version, context.Stack)
+		dependency, err := dependency.Resolve(dependencyManager, logger, clock, context.CNBPath, entry.Name, entry, context.Stack)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
 
-		logger.SelectedDependency(entry, dependency, clock.Now())
+		layerMetadata := build.NewLayerMetadata(dependency, context.BuildpackInfo.Version, nil)
 
 		legacySBOM := dependencyManager.GenerateBillOfMaterials(dependency)
 
@@ -86,19 +82,18 @@ func Build(
 			launchMetadata = packit.LaunchMetadata{BOM: legacySBOM}
 		}
 
-		cachedChecksum, ok := uvLayer.Metadata[DepKey].(string)
-		dependencyChecksum := dependency.Checksum
-		if dependencyChecksum == "" {
-			//nolint:staticcheck // SHA256 is only a fallback in case Checksum is not present
-			dependencyChecksum = dependency.SHA256
-		}
-
-		if ok && cachedChecksum != "" && cargo.Checksum(cachedChecksum).MatchString(dependencyChecksum) {
+		reusable, reason := layerMetadata.Matches(uvLayer.Metadata)
+		if reusable {
 			logger.Process("Reusing cached layer %s", uvLayer.Path)
 			logger.Break()
 
 			uvLayer.Launch, uvLayer.Build, uvLayer.Cache = launch, build, build
 
+			uvLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, uvLayer.Path, context.BuildpackInfo.SBOMFormats)
+			if err != nil {
+				return packit.BuildResult{}, err
+			}
+
 			return packit.BuildResult{
 				Layers: []packit.Layer{uvLayer},
 				Build:  buildMetadata,
@@ -106,6 +101,10 @@ func Build(
 			}, nil
 		}
 
+		if reason != "" {
+			logger.Process("Rebuilding cached layer %s: %s", uvLayer.Path, reason)
+		}
+
 		uvLayer, err = uvLayer.Reset()
 		if err != nil {
 			return packit.BuildResult{}, err
@@ -145,25 +144,9 @@ func Build(
 		logger.Action("Completed in %s", duration.Round(time.Millisecond))
 		logger.Break()
 
-		uvLayer.Metadata = map[string]interface{}{
-			DepKey: dependencyChecksum,
-		}
-
-		logger.GeneratingSBOM(uvLayer.Path)
-		var sbomContent sbom.SBOM
-		duration, err = clock.Measure(func() error {
-			sbomContent, err = sbomGenerator.GenerateFromDependency(dependency, uvLayer.Path)
-			return err
-		})
-		if err != nil {
-			return packit.BuildResult{}, err
-		}
-
-		logger.Action("Completed in %s", duration.Round(time.Millisecond))
-		logger.Break()
+		uvLayer.Metadata = layerMetadata.ToMap()
 
-		logger.FormattingSBOM(context.BuildpackInfo.SBOMFormats...)
-		uvLayer.SBOM, err = sbomContent.InFormats(context.BuildpackInfo.SBOMFormats...)
+		uvLayer.SBOM, err = sbom.GenerateForLayer(sbomGenerator, clock, logger, dependency, uvLayer.Path, context.BuildpackInfo.SBOMFormats)
 		if err != nil {
 			return packit.BuildResult{}, err
 		}
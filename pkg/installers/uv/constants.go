@@ -10,15 +10,14 @@ const (
 	Uv = "uv"
 	// LockfileName is the name of the uv lock file
 	LockfileName = "uv.lock"
+	// ConfigFileName is the name of the uv configuration file
+	ConfigFileName = "uv.toml"
+	// PyProjectTomlFile is the name of the python project file
+	PyProjectTomlFile = "pyproject.toml"
 
 	// CPython is the name of the python runtime dependency provided by the CPython buildpack: https://github.com/paketo-buildpacks/cpython
 	CPython = "cpython"
 
-	// This is the key name that we use to store the sha of the script we
-	// download in the layer metadata, which is used to determine if the uvs
-	// layer can be reused on during a rebuild.
-	DepKey = "dependency-sha"
-
 	UvArchiveTemplateName = "uv-%s-unknown-linux-gnu"
 
 	EnvVersion = "BP_UV_VERSION"
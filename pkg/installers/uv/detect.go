@@ -6,39 +6,92 @@
 package uv
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/paketo-buildpacks/packit/v2"
 	"github.com/paketo-buildpacks/packit/v2/fs"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pythonversion"
 )
 
 // Detect will return a packit.DetectFunc that will be invoked during the
 // detect phase of the buildpack lifecycle.
 //
-// Detection always passes, and will contribute a  Build Plan that provides uv.
-func Detect() packit.DetectFunc {
+// Detection passes when a uv.lock declaring requires-python, a pyproject.toml
+// with a [tool.uv] table or a uv.toml is present and will contribute a Build
+// Plan that provides uv. The signal that matched is logged.
+//
+// uv manages the interpreter itself and honours .python-version, so a pinned
+// version is only checked against requires-python, taken from uv.lock or
+// failing that from pyproject.toml, and a warning is logged when they
+// conflict.
+func Detect(loader project.Loader, logger scribe.Emitter) packit.DetectFunc {
 	return func(context packit.DetectContext) (packit.DetectResult, error) {
-		lockfile := filepath.Join(context.WorkingDir, LockfileName)
-
-		if exists, err := fs.Exists(lockfile); err != nil {
+		proj, err := loader.Load(context.WorkingDir)
+		if err != nil {
 			return packit.DetectResult{}, err
-		} else if !exists {
-			return packit.DetectResult{}, packit.Fail.WithMessage("%s is not present", LockfileName)
 		}
 
-		parser := NewLockfileParser()
-		pythonVersion, err := parser.ParsePythonVersion(lockfile)
+		uvLock, err := proj.UvLock()
 		if err != nil {
 			return packit.DetectResult{}, err
 		}
 
-		if pythonVersion == "" {
-			return packit.DetectResult{}, packit.Fail.WithMessage("%s must include requires-python", LockfileName)
+		var signal, pythonVersion, pythonVersionSource string
+
+		if uvLock != nil {
+			signal = LockfileName
+
+			pythonVersion = uvLock.RequiresPython()
+			if pythonVersion == "" {
+				return packit.DetectResult{}, packit.Fail.WithMessage("%s must include requires-python", LockfileName)
+			}
+			pythonVersionSource = LockfileName
+		} else {
+			// Without uv.lock, pyproject.toml tells whether this is a uv project
+			// and which python it requires, so it is only read here.
+			pyProject, err := proj.PyProject()
+			if err != nil {
+				return packit.DetectResult{}, err
+			}
+
+			configFileExists, err := fs.Exists(filepath.Join(proj.Path, ConfigFileName))
+			if err != nil {
+				return packit.DetectResult{}, err
+			}
+
+			switch {
+			case pyProject.HasTool(Uv):
+				signal = fmt.Sprintf("[tool.uv] in %s", PyProjectTomlFile)
+
+			case configFileExists:
+				signal = ConfigFileName
+
+			default:
+				return packit.DetectResult{}, packit.Fail.WithMessage("neither %s, %s nor a %s with [tool.uv] are present", LockfileName, ConfigFileName, PyProjectTomlFile)
+			}
+
+			if pyProject.RequiresPython() != "" {
+				pythonVersion = pyProject.RequiresPython()
+				pythonVersionSource = PyProjectTomlFile
+			}
+		}
+
+		logger.Detail("Detected uv project using %s", signal)
+		if pythonVersion != "" {
+			logger.Detail("Python requirement %q from %s", pythonVersion, pythonVersionSource)
 		}
 
+		pythonversion.ResolveEntry(logger, proj.PythonVersionEntry, pythonversion.Requirement{
+			Version: pythonVersion,
+			Source:  pythonVersionSource,
+		})
+
 		plan := packit.BuildPlan{
 			Provides: []packit.BuildPlanProvision{
 				{Name: Uv},
@@ -6,15 +6,18 @@
 package uv_test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
 	"github.com/sclevine/spec"
 
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/build"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/uv"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 
 	. "github.com/onsi/gomega"
 )
@@ -23,6 +26,8 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
 
+		buffer *bytes.Buffer
+
 		workingDir string
 
 		detect packit.DetectFunc
@@ -35,7 +40,8 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 
 		Expect(os.WriteFile(filepath.Join(workingDir, uv.LockfileName), []byte(`requires-python = "==3.13.0"`), 0755)).To(Succeed())
 
-		detect = uv.Detect()
+		buffer = bytes.NewBuffer(nil)
+		detect = uv.Detect(project.NewLoader(), scribe.NewEmitter(buffer))
 	})
 
 	it.After(func() {
@@ -96,7 +102,84 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 			_, err := detect(packit.DetectContext{
 				WorkingDir: workingDir,
 			})
-			Expect(err).To(MatchError(packit.Fail.WithMessage("uv.lock is not present")))
+			Expect(err).To(MatchError(packit.Fail.WithMessage("neither uv.lock, uv.toml nor a pyproject.toml with [tool.uv] are present")))
+		})
+
+		context("when pyproject.toml has a [tool.uv] table", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, uv.PyProjectTomlFile), []byte(`[project]
+name = "app"
+requires-python = ">=3.12"
+
+[tool.uv]
+dev-dependencies = []
+`), 0644)).To(Succeed())
+			})
+
+			it("detects and takes requires-python from pyproject.toml", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan).To(Equal(packit.BuildPlan{
+					Provides: []packit.BuildPlanProvision{
+						{Name: uv.Uv},
+					},
+				}))
+				Expect(buffer.String()).To(ContainSubstring("Detected uv project using [tool.uv] in pyproject.toml"))
+				Expect(buffer.String()).To(ContainSubstring(`Python requirement ">=3.12" from pyproject.toml`))
+			})
+
+			context("and .python-version does not satisfy requires-python", func() {
+				it.Before(func() {
+					Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.11\n"), 0644)).To(Succeed())
+				})
+
+				it("warns about the conflict", func() {
+					_, err := detect(packit.DetectContext{
+						WorkingDir: workingDir,
+					})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(buffer.String()).To(ContainSubstring(`Warning: .python-version pins python 3.11 which does not satisfy ">=3.12" from pyproject.toml`))
+				})
+			})
+		})
+
+		context("when pyproject.toml has no [tool.uv] table", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, uv.PyProjectTomlFile), []byte(`[project]
+name = "app"
+requires-python = ">=3.12"
+`), 0644)).To(Succeed())
+			})
+
+			it("fails detection", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(packit.Fail.WithMessage("neither uv.lock, uv.toml nor a pyproject.toml with [tool.uv] are present")))
+			})
+		})
+
+		context("when uv.toml is present", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, uv.ConfigFileName), []byte(`python-preference = "managed"
+`), 0644)).To(Succeed())
+			})
+
+			it("detects without a python requirement", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan).To(Equal(packit.BuildPlan{
+					Provides: []packit.BuildPlanProvision{
+						{Name: uv.Uv},
+					},
+				}))
+				Expect(buffer.String()).To(ContainSubstring("Detected uv project using uv.toml"))
+				Expect(buffer.String()).NotTo(ContainSubstring("Python requirement"))
+			})
 		})
 	})
 
@@ -159,5 +242,96 @@ func testDetect(t *testing.T, context spec.G, it spec.S) {
 				Expect(err).To(MatchError(ContainSubstring("toml: line 1: expected '.' or '=', but got '<' instead")))
 			})
 		})
+
+		context("when pyproject.toml cannot be parsed", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, uv.PyProjectTomlFile), []byte("%%%"), 0644)).To(Succeed())
+			})
+
+			it("detects using uv.lock", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan).To(Equal(packit.BuildPlan{
+					Provides: []packit.BuildPlanProvision{
+						{Name: uv.Uv},
+					},
+				}))
+				Expect(buffer.String()).To(ContainSubstring("Detected uv project using uv.lock"))
+			})
+
+			context("and uv.lock is not present", func() {
+				it.Before(func() {
+					Expect(os.RemoveAll(filepath.Join(workingDir, uv.LockfileName))).To(Succeed())
+				})
+
+				it("returns the error", func() {
+					_, err := detect(packit.DetectContext{
+						WorkingDir: workingDir,
+					})
+					Expect(err).To(MatchError(ContainSubstring("toml: line 1")))
+				})
+			})
+		})
+	})
+
+	context("with .python-version", func() {
+		context("when it conflicts with requires-python", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.12\n"), 0644)).To(Succeed())
+			})
+
+			it("passes detection and warns", func() {
+				result, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Plan.Provides).To(Equal([]packit.BuildPlanProvision{{Name: uv.Uv}}))
+				Expect(buffer.String()).To(ContainSubstring(`Warning: .python-version pins python 3.12 which does not satisfy "3.13.0" from uv.lock`))
+			})
+		})
+
+		context("when it satisfies requires-python", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.13.0\n"), 0644)).To(Succeed())
+			})
+
+			it("does not warn", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buffer.String()).NotTo(ContainSubstring("Warning"))
+			})
+		})
+	})
+
+	context("when BP_PYTHON_PROJECT_PATH points to a sub-directory", func() {
+		it.Before(func() {
+			Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+			Expect(os.Rename(filepath.Join(workingDir, uv.LockfileName), filepath.Join(workingDir, "services", "api", uv.LockfileName))).To(Succeed())
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+		})
+
+		it("detects the project in the sub-directory", func() {
+			_, err := detect(packit.DetectContext{
+				WorkingDir: workingDir,
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		context("when the sub-directory does not exist", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+			})
+
+			it("returns an error", func() {
+				_, err := detect(packit.DetectContext{
+					WorkingDir: workingDir,
+				})
+				Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`)))
+			})
+		})
 	})
 }
@@ -17,6 +17,5 @@ func TestUnit(t *testing.T) {
 	suite("Build", testBuild)
 	suite("Detect", testDetect, spec.Sequential())
 	suite("InstallProcess", testUvInstallProcess)
-	suite("Parser", testUvLockParser)
 	suite.Run(t)
 }
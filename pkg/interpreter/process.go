@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interpreter
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
+)
+
+// identifyScript prints the minor version of the interpreter along with its
+// ABI tag, e.g. `3.12-cpython-312-x86_64-linux-gnu`. Both drive the location
+// of the site packages and the validity of the compiled bytecode.
+const identifyScript = `import sys, sysconfig; print("%d.%d-%s" % (sys.version_info[0], sys.version_info[1], sysconfig.get_config_var("SOABI") or sys.implementation.cache_tag))`
+
+// Process identifies the python interpreter available in the build
+// environment.
+type Process struct {
+	executable executable.Executable
+	logger     scribe.Emitter
+}
+
+// NewProcess creates an instance of the Process given an Executable that runs
+// `python`.
+func NewProcess(executable executable.Executable, logger scribe.Emitter) Process {
+	return Process{
+		executable: executable,
+		logger:     logger,
+	}
+}
+
+// Execute returns the identifier of the python interpreter, combining its
+// version and ABI.
+func (p Process) Execute() (string, error) {
+	output, buffer := executable.NewOutputWriter(p.logger)
+	identifier := bytes.NewBuffer(nil)
+
+	err := p.executable.Execute(pexec.Execution{
+		Args:   []string{"-c", identifyScript},
+		Stdout: identifier,
+		Stderr: output,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to identify python interpreter:\n%s\nerror: %w", buffer.String(), err)
+	}
+
+	return strings.TrimSpace(identifier.String()), nil
+}
+
+// Version returns the X.Y version of the interpreter given its identifier as
+// returned by Execute.
+func Version(identifier string) string {
+	version, _, _ := strings.Cut(identifier, "-")
+	return version
+}
+
+// ABI returns the ABI tag of the wheels built for the interpreter given its
+// identifier as returned by Execute, e.g. cp312, or an empty string when it
+// is not a CPython interpreter.
+func ABI(identifier string) string {
+	_, soabi, _ := strings.Cut(identifier, "-")
+	implementation, tag, ok := strings.Cut(soabi, "-")
+	if !ok || implementation != "cpython" {
+		return ""
+	}
+
+	tag, _, _ = strings.Cut(tag, "-")
+	return "cp" + tag
+}
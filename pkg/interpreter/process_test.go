@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interpreter_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/interpreter"
+
+	. "github.com/onsi/gomega"
+)
+
+func testProcess(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		executable *fakes.Executable
+		buffer     *bytes.Buffer
+
+		process interpreter.Process
+	)
+
+	it.Before(func() {
+		executable = &fakes.Executable{}
+		executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+			_, err := fmt.Fprintln(execution.Stdout, "3.12-cpython-312-x86_64-linux-gnu")
+			return err
+		}
+
+		buffer = bytes.NewBuffer(nil)
+
+		process = interpreter.NewProcess(executable, scribe.NewEmitter(buffer))
+	})
+
+	context("Execute", func() {
+		it("returns the interpreter identifier", func() {
+			identifier, err := process.Execute()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identifier).To(Equal("3.12-cpython-312-x86_64-linux-gnu"))
+
+			Expect(executable.ExecuteCall.Receives.Execution.Args).To(HaveLen(2))
+			Expect(executable.ExecuteCall.Receives.Execution.Args[0]).To(Equal("-c"))
+			Expect(executable.ExecuteCall.Receives.Execution.Args[1]).To(ContainSubstring("SOABI"))
+		})
+
+		context("failure cases", func() {
+			context("when python fails", func() {
+				it.Before(func() {
+					executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+						_, err := fmt.Fprintln(execution.Stderr, "stderr output")
+						Expect(err).NotTo(HaveOccurred())
+						return errors.New("python failed")
+					}
+				})
+
+				it("returns an error", func() {
+					_, err := process.Execute()
+					Expect(err).To(MatchError(ContainSubstring("failed to identify python interpreter")))
+					Expect(err).To(MatchError(ContainSubstring("stderr output")))
+					Expect(err).To(MatchError(ContainSubstring("python failed")))
+				})
+			})
+		})
+	})
+
+	context("Version", func() {
+		it("returns the version of the interpreter", func() {
+			Expect(interpreter.Version("3.12-cpython-312-x86_64-linux-gnu")).To(Equal("3.12"))
+			Expect(interpreter.Version("3.8")).To(Equal("3.8"))
+		})
+	})
+
+	context("ABI", func() {
+		it("returns the ABI tag of the interpreter", func() {
+			Expect(interpreter.ABI("3.12-cpython-312-x86_64-linux-gnu")).To(Equal("cp312"))
+			Expect(interpreter.ABI("3.13-cpython-313t-x86_64-linux-gnu")).To(Equal("cp313t"))
+			Expect(interpreter.ABI("3.12-cpython-312")).To(Equal("cp312"))
+		})
+
+		it("returns nothing for the other interpreters", func() {
+			Expect(interpreter.ABI("3.10-pypy310-pp73-x86_64-linux-gnu")).To(BeEmpty())
+			Expect(interpreter.ABI("3.8")).To(BeEmpty())
+		})
+	})
+}
@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pep440
+
+// The retrieval of the dependencies, a module of its own, uses a copy of
+// pep440.go rather than depending on the buildpack.
+//go:generate sh -c "{ echo '// Code generated from pkg/pep440/pep440.go by go generate. DO NOT EDIT.'; echo; cat pep440.go; } > ../../dependency/retrieval/pep440/pep440.go"
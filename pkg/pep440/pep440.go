@@ -0,0 +1,456 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pep440 implements the version scheme of the python packages and
+// its mapping to the semver versions of buildpack.toml.
+package pep440
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// pattern is the version scheme of PEP 440, accepting the non
+// normalized forms it allows.
+// https://packaging.python.org/en/latest/specifications/version-specifiers/#appendix-parsing-version-strings-with-regular-expressions
+var pattern = regexp.MustCompile(`(?i)^v?` +
+	`(?:(?P<epoch>[0-9]+)!)?` +
+	`(?P<release>[0-9]+(?:\.[0-9]+)*)` +
+	`(?:[-_.]?(?P<pre_l>alpha|a|beta|b|preview|pre|c|rc)[-_.]?(?P<pre_n>[0-9]+)?)?` +
+	`(?:-(?P<post_n1>[0-9]+)|[-_.]?(?P<post_l>post|rev|r)[-_.]?(?P<post_n2>[0-9]+)?)?` +
+	`(?:[-_.]?(?P<dev_l>dev)[-_.]?(?P<dev_n>[0-9]+)?)?` +
+	`(?:\+(?P<local>[a-z0-9]+(?:[-_.][a-z0-9]+)*))?$`)
+
+// preReleaseLabels maps the prerelease spellings of PEP 440 to their
+// normalized form.
+var preReleaseLabels = map[string]string{
+	"a":       "a",
+	"alpha":   "a",
+	"b":       "b",
+	"beta":    "b",
+	"c":       "rc",
+	"pre":     "rc",
+	"preview": "rc",
+	"rc":      "rc",
+}
+
+// semverPreReleaseLabels maps the normalized prerelease labels to semver
+// prerelease identifiers ordered the same way.
+var semverPreReleaseLabels = map[string]string{
+	"a":  "alpha",
+	"b":  "beta",
+	"rc": "rc",
+}
+
+// Version is a version following PEP 440.
+type Version struct {
+	Epoch     int
+	Release   []int
+	PreLabel  string
+	PreNumber int
+	Post      *int
+	Dev       *int
+	Local     string
+}
+
+// Parse parses version according to PEP 440.
+func Parse(version string) (Version, error) {
+	groups := pattern.FindStringSubmatch(strings.TrimSpace(version))
+	if groups == nil {
+		return Version{}, fmt.Errorf("invalid PEP 440 version %q", version)
+	}
+
+	group := func(name string) string {
+		return groups[pattern.SubexpIndex(name)]
+	}
+
+	// The pattern only lets digits through, Atoi fails only on overflow
+	number := func(value string) (int, error) {
+		if value == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid PEP 440 version %q: %w", version, err)
+		}
+		return n, nil
+	}
+
+	var parsed Version
+	var err error
+
+	parsed.Epoch, err = number(group("epoch"))
+	if err != nil {
+		return Version{}, err
+	}
+
+	for _, part := range strings.Split(group("release"), ".") {
+		n, err := number(part)
+		if err != nil {
+			return Version{}, err
+		}
+		parsed.Release = append(parsed.Release, n)
+	}
+
+	if label := group("pre_l"); label != "" {
+		parsed.PreLabel = preReleaseLabels[strings.ToLower(label)]
+		parsed.PreNumber, err = number(group("pre_n"))
+		if err != nil {
+			return Version{}, err
+		}
+	}
+
+	if group("post_n1") != "" || group("post_l") != "" {
+		post, err := number(group("post_n1") + group("post_n2"))
+		if err != nil {
+			return Version{}, err
+		}
+		parsed.Post = &post
+	}
+
+	if group("dev_l") != "" {
+		dev, err := number(group("dev_n"))
+		if err != nil {
+			return Version{}, err
+		}
+		parsed.Dev = &dev
+	}
+
+	parsed.Local = strings.ToLower(strings.NewReplacer("-", ".", "_", ".").Replace(group("local")))
+
+	return parsed, nil
+}
+
+// IsPrerelease returns whether the version is a prerelease or a development
+// release.
+func (version Version) IsPrerelease() bool {
+	return version.PreLabel != "" || version.Dev != nil
+}
+
+// String returns the normalized form of the version.
+func (version Version) String() string {
+	var builder strings.Builder
+
+	if version.Epoch != 0 {
+		fmt.Fprintf(&builder, "%d!", version.Epoch)
+	}
+
+	for i, part := range version.Release {
+		if i > 0 {
+			builder.WriteString(".")
+		}
+		builder.WriteString(strconv.Itoa(part))
+	}
+
+	if version.PreLabel != "" {
+		fmt.Fprintf(&builder, "%s%d", version.PreLabel, version.PreNumber)
+	}
+	if version.Post != nil {
+		fmt.Fprintf(&builder, ".post%d", *version.Post)
+	}
+	if version.Dev != nil {
+		fmt.Fprintf(&builder, ".dev%d", *version.Dev)
+	}
+	if version.Local != "" {
+		fmt.Fprintf(&builder, "+%s", version.Local)
+	}
+
+	return builder.String()
+}
+
+// Compare returns -1, 0 or 1 when version is respectively older, the same
+// or newer than other, following the ordering of PEP 440.
+func (version Version) Compare(other Version) int {
+	if c := cmp.Compare(version.Epoch, other.Epoch); c != 0 {
+		return c
+	}
+
+	// Trailing zeros are not significant, 1.0 is 1.0.0
+	for i := 0; i < max(len(version.Release), len(other.Release)); i++ {
+		if c := cmp.Compare(releasePart(version.Release, i), releasePart(other.Release, i)); c != 0 {
+			return c
+		}
+	}
+
+	if c := compareKeys(version.preKey(), other.preKey()); c != 0 {
+		return c
+	}
+
+	if c := compareOptional(version.Post, other.Post, -1); c != 0 {
+		return c
+	}
+
+	if c := compareOptional(version.Dev, other.Dev, 1); c != 0 {
+		return c
+	}
+
+	return compareLocal(version.Local, other.Local)
+}
+
+// Semver returns the semver equivalent of the version, as used in
+// buildpack.toml, ordered the same way. The release is padded or cut to its
+// first three parts, the prerelease segment becomes the semver prerelease
+// and the remaining release parts and the post segment become build
+// metadata. A development release becomes the 0.dev.N prerelease, which
+// semver orders before the alpha, beta and rc ones as PEP 440 does.
+//
+// Versions with an epoch or a local segment have no semver equivalent, nor
+// do the development releases of prereleases and post releases, which semver
+// would order after them.
+func (version Version) Semver() (*semver.Version, error) {
+	if version.Epoch != 0 || version.Local != "" || (version.Dev != nil && (version.PreLabel != "" || version.Post != nil)) {
+		return nil, fmt.Errorf("version %s has no semver equivalent", version)
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d.%d.%d", releasePart(version.Release, 0), releasePart(version.Release, 1), releasePart(version.Release, 2))
+
+	switch {
+	case version.PreLabel != "":
+		fmt.Fprintf(&builder, "-%s.%d", semverPreReleaseLabels[version.PreLabel], version.PreNumber)
+	case version.Dev != nil:
+		fmt.Fprintf(&builder, "-0.dev.%d", *version.Dev)
+	}
+
+	var metadata []string
+	if len(version.Release) > 3 {
+		for _, part := range version.Release[3:] {
+			metadata = append(metadata, strconv.Itoa(part))
+		}
+	}
+	if version.Post != nil {
+		metadata = append(metadata, fmt.Sprintf("post%d", *version.Post))
+	}
+	if len(metadata) > 0 {
+		fmt.Fprintf(&builder, "+%s", strings.Join(metadata, "."))
+	}
+
+	return semver.StrictNewVersion(builder.String())
+}
+
+// ParseSemver parses the semver equivalent of a PEP 440 version as returned
+// by Semver, e.g. 2024.0.1+post1 for 2024.0.1.post1.
+func ParseSemver(version string) (Version, error) {
+	semverVersion, err := semver.StrictNewVersion(version)
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid semver version %q: %w", version, err)
+	}
+
+	invalid := fmt.Errorf("%s is not the semver equivalent of a PEP 440 version", version)
+
+	parsed := Version{Release: []int{int(semverVersion.Major()), int(semverVersion.Minor()), int(semverVersion.Patch())}}
+
+	if prerelease := semverVersion.Prerelease(); prerelease != "" {
+		label, number, _ := strings.Cut(strings.TrimPrefix(prerelease, "0."), ".")
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return Version{}, invalid
+		}
+
+		if label == "dev" {
+			parsed.Dev = &n
+		}
+		for preLabel, semverLabel := range semverPreReleaseLabels {
+			if semverLabel == label {
+				parsed.PreLabel, parsed.PreNumber = preLabel, n
+			}
+		}
+	}
+
+	if semverVersion.Metadata() != "" {
+		for _, part := range strings.Split(semverVersion.Metadata(), ".") {
+			post, isPost := strings.CutPrefix(part, "post")
+			n, err := strconv.Atoi(post)
+			switch {
+			case err != nil:
+				return Version{}, invalid
+			case isPost:
+				parsed.Post = &n
+			default:
+				parsed.Release = append(parsed.Release, n)
+			}
+		}
+	}
+
+	// Only the versions Semver returns map back
+	roundTrip, err := parsed.Semver()
+	if err != nil || roundTrip.Original() != version {
+		return Version{}, invalid
+	}
+
+	return parsed, nil
+}
+
+// FromSemver returns the PEP 440 version a python package listed in
+// buildpack.toml was published with, as needed to pin it with ==, e.g.
+// 2024.0.1.post1 for 2024.0.1+post1. Versions which are not the semver
+// equivalent of a PEP 440 version are returned as is.
+func FromSemver(version string) string {
+	parsed, err := ParseSemver(version)
+	if err != nil {
+		return version
+	}
+	return parsed.String()
+}
+
+func releasePart(release []int, i int) int {
+	if i < len(release) {
+		return release[i]
+	}
+	return 0
+}
+
+// preKey orders the prerelease segment: a development release of a final
+// release comes before its prereleases, which come before the final release.
+func (version Version) preKey() []int {
+	labels := map[string]int{"a": 0, "b": 1, "rc": 2}
+
+	switch {
+	case version.PreLabel != "":
+		return []int{1, labels[version.PreLabel], version.PreNumber}
+	case version.Post == nil && version.Dev != nil:
+		return []int{0}
+	default:
+		return []int{2}
+	}
+}
+
+func compareKeys(a, b []int) int {
+	for i := 0; i < min(len(a), len(b)); i++ {
+		if c := cmp.Compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// compareOptional compares optional segments, missing is the result of a
+// missing a against a present b.
+func compareOptional(a, b *int, missing int) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return missing
+	case b == nil:
+		return -missing
+	}
+	return cmp.Compare(*a, *b)
+}
+
+// compareLocal compares local segments: a version without one comes first,
+// numeric parts come after alphanumeric ones.
+func compareLocal(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return -1
+	case b == "":
+		return 1
+	}
+
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < min(len(aParts), len(bParts)); i++ {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = cmp.Compare(aNumber, bNumber)
+		case aErr == nil:
+			c = 1
+		case bErr == nil:
+			c = -1
+		default:
+			c = strings.Compare(aParts[i], bParts[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(aParts), len(bParts))
+}
+
+// specifierPattern is a clause of a PEP 440 version specifier.
+var specifierPattern = regexp.MustCompile(`^\s*(~=|===|==|!=|<=|>=|<|>)\s*([^\s,]+)\s*$`)
+
+// Satisfies returns whether the version satisfies specifiers, a comma
+// separated list of PEP 440 clauses such as a requires-python, e.g.
+// ">=3.9,<4.0". Empty specifiers are satisfied by any version.
+func (version Version) Satisfies(specifiers string) (bool, error) {
+	if strings.TrimSpace(specifiers) == "" {
+		return true, nil
+	}
+
+	for _, clause := range strings.Split(specifiers, ",") {
+		groups := specifierPattern.FindStringSubmatch(clause)
+		if groups == nil {
+			return false, fmt.Errorf("invalid specifier %q", clause)
+		}
+		operator, operand := groups[1], groups[2]
+
+		if operator == "===" {
+			if version.String() != operand {
+				return false, nil
+			}
+			continue
+		}
+
+		prefix, wildcard := strings.CutSuffix(operand, ".*")
+		if wildcard && operator != "==" && operator != "!=" {
+			return false, fmt.Errorf("invalid specifier %q, only == and != accept a wildcard", clause)
+		}
+
+		other, err := Parse(prefix)
+		if err != nil {
+			return false, fmt.Errorf("invalid specifier %q: %w", clause, err)
+		}
+
+		var satisfied bool
+		switch operator {
+		case "==":
+			satisfied = version.Compare(other) == 0 || (wildcard && version.hasPrefix(other.Release))
+		case "!=":
+			satisfied = version.Compare(other) != 0 && !(wildcard && version.hasPrefix(other.Release))
+		case "<=":
+			satisfied = version.Compare(other) <= 0
+		case ">=":
+			satisfied = version.Compare(other) >= 0
+		case "<":
+			satisfied = version.Compare(other) < 0
+		case ">":
+			satisfied = version.Compare(other) > 0
+		case "~=":
+			if len(other.Release) < 2 {
+				return false, fmt.Errorf("invalid specifier %q, ~= needs at least two release parts", clause)
+			}
+			satisfied = version.Compare(other) >= 0 && version.hasPrefix(other.Release[:len(other.Release)-1])
+		}
+
+		if !satisfied {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// hasPrefix returns whether the release of the version starts with prefix,
+// missing parts of the release counting as zeros.
+func (version Version) hasPrefix(prefix []int) bool {
+	for i, part := range prefix {
+		if releasePart(version.Release, i) != part {
+			return false
+		}
+	}
+	return true
+}
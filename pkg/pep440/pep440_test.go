@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pep440_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pep440"
+
+	. "github.com/onsi/gomega"
+)
+
+func testPEP440(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	parse := func(version string) pep440.Version {
+		parsed, err := pep440.Parse(version)
+		Expect(err).NotTo(HaveOccurred())
+		return parsed
+	}
+
+	context("Parse", func() {
+		for _, tc := range []struct {
+			version, normalized string
+			prerelease          bool
+		}{
+			{"2.3.2", "2.3.2", false},
+			{"v1.0", "1.0", false},
+			{"2024.0.1.post1", "2024.0.1.post1", false},
+			{"1.0-1", "1.0.post1", false},
+			{"1.0.rev2", "1.0.post2", false},
+			{"1.0.0-Alpha.1", "1.0.0a1", true},
+			{"1.0c2", "1.0rc2", true},
+			{"1.0b", "1.0b0", true},
+			{"1.0.dev", "1.0.dev0", true},
+			{"1.0rc1.post2.dev3", "1.0rc1.post2.dev3", true},
+			{"1!2.0", "1!2.0", false},
+			{"1.0+Ubuntu-1", "1.0+ubuntu.1", false},
+		} {
+			it("normalizes "+tc.version, func() {
+				version := parse(tc.version)
+				Expect(version.String()).To(Equal(tc.normalized))
+				Expect(version.IsPrerelease()).To(Equal(tc.prerelease))
+			})
+		}
+
+		it("rejects invalid versions", func() {
+			for _, version := range []string{"", "latest", "1.0-", "1..0", "1.0+"} {
+				_, err := pep440.Parse(version)
+				Expect(err).To(MatchError(ContainSubstring("invalid PEP 440 version")), version)
+			}
+		})
+	})
+
+	context("Compare", func() {
+		it("follows the ordering of the specification", func() {
+			ordered := []string{
+				"1.0.dev456",
+				"1.0a1",
+				"1.0a2.dev456",
+				"1.0a12.dev456",
+				"1.0a12",
+				"1.0b1.dev456",
+				"1.0b2",
+				"1.0b2.post345.dev456",
+				"1.0b2.post345",
+				"1.0rc1.dev456",
+				"1.0rc1",
+				"1.0",
+				"1.0+abc.5",
+				"1.0+abc.7",
+				"1.0+5",
+				"1.0.post456.dev34",
+				"1.0.post456",
+				"1.0.15",
+				"1.1.dev1",
+				"1!0.1",
+			}
+
+			for i := range ordered {
+				for j := range ordered {
+					expected := 0
+					switch {
+					case i < j:
+						expected = -1
+					case i > j:
+						expected = 1
+					}
+					Expect(parse(ordered[i]).Compare(parse(ordered[j]))).To(Equal(expected), "comparing %s to %s", ordered[i], ordered[j])
+				}
+			}
+		})
+
+		it("ignores trailing zeros", func() {
+			Expect(parse("1.0").Compare(parse("1.0.0"))).To(Equal(0))
+		})
+	})
+
+	context("Semver", func() {
+		for _, tc := range []struct {
+			version, semver string
+		}{
+			{"2.3", "2.3.0"},
+			{"2026.0.3", "2026.0.3"},
+			{"2024.0.1.post1", "2024.0.1+post1"},
+			{"1.2.3.4", "1.2.3+4"},
+			{"1.2.3.4.post5", "1.2.3+4.post5"},
+			{"2.0.0b1", "2.0.0-beta.1"},
+			{"2.0.0.dev1", "2.0.0-0.dev.1"},
+		} {
+			it("converts "+tc.version, func() {
+				semver, err := parse(tc.version).Semver()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(semver.Original()).To(Equal(tc.semver))
+
+				back, err := pep440.ParseSemver(tc.semver)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(back.Compare(parse(tc.version))).To(Equal(0))
+			})
+		}
+
+		it("keeps the ordering of PEP 440", func() {
+			ordered := []string{"1.0.dev0", "1.0.dev456", "1.0a1", "1.0b2", "1.0rc1", "1.0", "1.0.post1", "1.0.1.dev1", "1.0.1"}
+
+			var semvers []*semver.Version
+			for _, version := range ordered {
+				semverVersion, err := parse(version).Semver()
+				Expect(err).NotTo(HaveOccurred())
+				semvers = append(semvers, semverVersion)
+			}
+
+			for i := 1; i < len(semvers); i++ {
+				Expect(semvers[i-1].Compare(semvers[i])).To(BeNumerically("<=", 0), "comparing %s to %s", semvers[i-1], semvers[i])
+				if ordered[i] != "1.0.post1" {
+					Expect(semvers[i-1].LessThan(semvers[i])).To(BeTrue(), "comparing %s to %s", semvers[i-1], semvers[i])
+				}
+			}
+		})
+
+		it("fails on versions without semver equivalent", func() {
+			for _, version := range []string{"1!2.0", "1.0+local", "2.0.0rc2.dev1", "1.0.post1.dev2"} {
+				_, err := parse(version).Semver()
+				Expect(err).To(MatchError(ContainSubstring("has no semver equivalent")), version)
+			}
+		})
+	})
+
+	context("FromSemver", func() {
+		for _, tc := range []struct {
+			version, pep440 string
+		}{
+			{"26.0.1", "26.0.1"},
+			{"26.0", "26.0"},
+			{"2024.0.1+post1", "2024.0.1.post1"},
+			{"1.2.3+4", "1.2.3.4"},
+			{"1.2.3+4.5.post2", "1.2.3.4.5.post2"},
+			{"2.0.0-alpha.1", "2.0.0a1"},
+			{"2.0.0-rc.2+post1", "2.0.0rc2.post1"},
+			{"2.0.0-0.dev.3", "2.0.0.dev3"},
+			{"2.0.0-dev.3", "2.0.0-dev.3"},
+			{"2.0.0-beta.1.dev.3", "2.0.0-beta.1.dev.3"},
+			{"2.0.0+local", "2.0.0+local"},
+			{"2.0.0-preview", "2.0.0-preview"},
+			{"1.2.3+post1.4", "1.2.3+post1.4"},
+		} {
+			it("converts "+tc.version, func() {
+				Expect(pep440.FromSemver(tc.version)).To(Equal(tc.pep440))
+			})
+		}
+	})
+
+	context("Satisfies", func() {
+		for _, tc := range []struct {
+			version, specifiers string
+			satisfied           bool
+		}{
+			{"3.12", "", true},
+			{"3.12", ">=3.9,<4.0", true},
+			{"3.8", ">=3.9,<4.0", false},
+			{"3.12", "<4.0,>=3.10", true},
+			{"3.9", ">3.9", false},
+			{"3.9", "<=3.9", true},
+			{"3.12", "==3.12.*", true},
+			{"3.13", "==3.12.*", false},
+			{"3.12", "!=3.12.*", false},
+			{"3.12", "!=3.11", true},
+			{"3.12", "==3.12.0", true},
+			{"3.12", "~=3.10", true},
+			{"4.0", "~=3.10", false},
+			{"3.11", "~=3.10.1", false},
+			{"3.12", "===3.12", true},
+		} {
+			it("checks "+tc.version+" against "+tc.specifiers, func() {
+				satisfied, err := parse(tc.version).Satisfies(tc.specifiers)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(satisfied).To(Equal(tc.satisfied))
+			})
+		}
+
+		it("rejects invalid specifiers", func() {
+			for _, specifiers := range []string{"3.9", ">=3.9;", ">=3.*", "~=3", ">=latest"} {
+				_, err := parse("3.12").Satisfies(specifiers)
+				Expect(err).To(HaveOccurred(), specifiers)
+			}
+		})
+	})
+
+	context("the copy of the retrieval", func() {
+		it("is up to date, see go generate", func() {
+			source, err := os.ReadFile("pep440.go")
+			Expect(err).NotTo(HaveOccurred())
+
+			generated, err := os.ReadFile("../../dependency/retrieval/pep440/pep440.go")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(generated)).To(Equal("// Code generated from pkg/pep440/pep440.go by go generate. DO NOT EDIT.\n\n" + string(source)))
+		})
+	})
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+)
+
+// The lock files below mimic a project with a few thousand locked packages.
+const benchmarkPackages = 5000
+
+func benchmarkUvLock() []byte {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("version = 1\nrequires-python = \">=3.12\"\n")
+	for i := range benchmarkPackages {
+		fmt.Fprintf(&buffer, `
+[[package]]
+name = "package-%[1]d"
+version = "1.0.%[1]d"
+source = { registry = "https://pypi.org/simple" }
+sdist = { url = "https://files.pythonhosted.org/package-%[1]d.tar.gz", hash = "sha256:%064[1]d", size = 1024 }
+wheels = [
+    { url = "https://files.pythonhosted.org/package-%[1]d.whl", hash = "sha256:%064[1]d", size = 1024 },
+]
+`, i)
+	}
+
+	return buffer.Bytes()
+}
+
+func benchmarkPipfileLock() []byte {
+	packages := map[string]any{}
+	for i := range benchmarkPackages {
+		packages[fmt.Sprintf("package-%d", i)] = map[string]any{
+			"hashes":  []string{fmt.Sprintf("sha256:%064d", i), fmt.Sprintf("sha256:%064d", i+1)},
+			"version": fmt.Sprintf("==1.0.%d", i),
+		}
+	}
+
+	content, err := json.Marshal(map[string]any{
+		"_meta":   map[string]any{"requires": map[string]string{"python_version": "3.12"}},
+		"default": packages,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return content
+}
+
+func BenchmarkParseUvLock(b *testing.B) {
+	content := benchmarkUvLock()
+	b.SetBytes(int64(len(content)))
+
+	for b.Loop() {
+		if _, err := project.ParseUvLock(bytes.NewReader(content)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeUvLock is the baseline of BenchmarkParseUvLock: decoding the
+// whole lock file to read requires-python.
+func BenchmarkDecodeUvLock(b *testing.B) {
+	content := benchmarkUvLock()
+	b.SetBytes(int64(len(content)))
+
+	for b.Loop() {
+		var lock struct {
+			RequiresPython string `toml:"requires-python"`
+		}
+		if _, err := toml.NewDecoder(bytes.NewReader(content)).Decode(&lock); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParsePipfileLock(b *testing.B) {
+	content := benchmarkPipfileLock()
+	b.SetBytes(int64(len(content)))
+
+	for b.Loop() {
+		if _, err := project.ParsePipfileLock(bytes.NewReader(content)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodePipfileLock is the baseline of BenchmarkParsePipfileLock:
+// unmarshalling the whole lock file to read _meta.
+func BenchmarkDecodePipfileLock(b *testing.B) {
+	content := benchmarkPipfileLock()
+	b.SetBytes(int64(len(content)))
+
+	for b.Loop() {
+		var lock struct {
+			Meta json.RawMessage `json:"_meta"`
+		}
+		if err := json.Unmarshal(content, &lock); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoad(b *testing.B) {
+	workingDir := b.TempDir()
+
+	files := map[string][]byte{
+		"pyproject.toml":  []byte("[project]\nrequires-python = \">=3.12\"\n\n[tool.uv]\n"),
+		"uv.lock":         benchmarkUvLock(),
+		"Pipfile":         []byte("[requires]\npython_version = \"3.12\"\n"),
+		"Pipfile.lock":    benchmarkPipfileLock(),
+		".python-version": []byte("3.12\n"),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workingDir, name), content, 0644); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("FileLoader", func(b *testing.B) {
+		loader := project.NewLoader()
+		for b.Loop() {
+			if _, err := loader.Load(workingDir); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// The detectors of the six package managers share a CachedLoader.
+	b.Run("CachedLoader", func(b *testing.B) {
+		for b.Loop() {
+			loader := project.NewCachedLoader(project.NewLoader())
+			for range 6 {
+				if _, err := loader.Load(workingDir); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
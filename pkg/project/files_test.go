@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+
+	. "github.com/onsi/gomega"
+)
+
+func testFiles(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("ParsePyProject", func() {
+		it("parses the python requirements", func() {
+			pyProject, err := project.ParsePyProject(strings.NewReader(`[project]
+requires-python = "==3.12.1"
+
+[tool.poetry.dependencies]
+python = "^3.12"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pyProject.RequiresPython()).To(Equal("3.12.1"))
+			Expect(pyProject.PoetryPython()).To(Equal("^3.12"))
+		})
+
+		it("reports the build system and the tool tables", func() {
+			pyProject, err := project.ParsePyProject(strings.NewReader(`[build-system]
+requires = ["poetry-core"]
+build-backend = "poetry.core.masonry.api"
+
+[tool.uv.sources]
+httpx = { git = "https://github.com/encode/httpx" }
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pyProject.HasBuildSystem()).To(BeTrue())
+			Expect(pyProject.BuildBackend()).To(Equal("poetry.core.masonry.api"))
+			Expect(pyProject.HasTool("uv")).To(BeTrue())
+			Expect(pyProject.HasTool("poetry")).To(BeFalse())
+		})
+
+		it("treats a missing file as empty", func() {
+			var pyProject *project.PyProject
+			Expect(pyProject.RequiresPython()).To(BeEmpty())
+			Expect(pyProject.HasBuildSystem()).To(BeFalse())
+			Expect(pyProject.HasTool("uv")).To(BeFalse())
+		})
+
+		it("fails on malformed content", func() {
+			_, err := project.ParsePyProject(strings.NewReader("%%%"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	context("ParseUvLock", func() {
+		it("parses the header", func() {
+			uvLock, err := project.ParseUvLock(strings.NewReader(`version = 1
+requires-python = "==3.13.0"
+resolution-markers = [
+    "python_full_version >= '3.13'",
+]
+
+[options]
+exclude-newer = "2024-01-01T00:00:00Z"
+
+[[package]]
+name = "anyio"
+version = "4.4.0"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uvLock.Version()).To(Equal(1))
+			Expect(uvLock.RequiresPython()).To(Equal("3.13.0"))
+		})
+
+		it("stops reading at the first table", func() {
+			uvLock, err := project.ParseUvLock(strings.NewReader(`requires-python = ">=3.12"
+
+[[package]]
+this is not toml
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uvLock.RequiresPython()).To(Equal(">=3.12"))
+		})
+
+		it("returns an empty string without requires-python", func() {
+			uvLock, err := project.ParseUvLock(strings.NewReader(""))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uvLock.RequiresPython()).To(BeEmpty())
+		})
+
+		it("fails on a malformed header", func() {
+			_, err := project.ParseUvLock(strings.NewReader("%%%"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	context("ParsePixiToml", func() {
+		it("parses a python version specification", func() {
+			pixiToml, err := project.ParsePixiToml(strings.NewReader(`[dependencies]
+python = ">=3.11,<3.13"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pixiToml.PythonVersion()).To(Equal(">=3.11,<3.13"))
+		})
+
+		it("parses a python dependency table", func() {
+			pixiToml, err := project.ParsePixiToml(strings.NewReader(`[dependencies]
+python = { version = "3.12.*", channel = "conda-forge" }
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pixiToml.PythonVersion()).To(Equal("3.12.*"))
+		})
+
+		it("returns an empty string without python dependency", func() {
+			pixiToml, err := project.ParsePixiToml(strings.NewReader(`[dependencies]
+numpy = "*"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pixiToml.PythonVersion()).To(BeEmpty())
+		})
+
+		it("fails on malformed content", func() {
+			_, err := project.ParsePixiToml(strings.NewReader("%%%"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	context("ParsePipfile", func() {
+		it("parses python_version", func() {
+			pipfile, err := project.ParsePipfile(strings.NewReader(`[requires]
+python_version = "3.12"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipfile.PythonVersion()).To(Equal("3.12"))
+		})
+
+		it("prefers python_full_version", func() {
+			pipfile, err := project.ParsePipfile(strings.NewReader(`[requires]
+python_version = "3.12"
+python_full_version = "3.12.4"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipfile.PythonVersion()).To(Equal("3.12.4"))
+		})
+
+		it("returns an empty string without [requires]", func() {
+			pipfile, err := project.ParsePipfile(strings.NewReader(`[packages]
+requests = "*"
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipfile.PythonVersion()).To(BeEmpty())
+		})
+
+		it("fails on malformed content", func() {
+			_, err := project.ParsePipfile(strings.NewReader("%%%"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	context("ParsePipfileLock", func() {
+		it("parses _meta.requires.python_version", func() {
+			lock, err := project.ParsePipfileLock(strings.NewReader(`{"_meta": {"requires": {"python_version": "3.11"}}, "default": {}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.PythonVersion()).To(Equal("3.11"))
+		})
+
+		it("prefers _meta.requires.python_full_version", func() {
+			lock, err := project.ParsePipfileLock(strings.NewReader(`{"_meta": {"requires": {"python_version": "3.11", "python_full_version": "3.11.9"}}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.PythonVersion()).To(Equal("3.11.9"))
+		})
+
+		it("skips the keys preceding _meta", func() {
+			lock, err := project.ParsePipfileLock(strings.NewReader(`{"default": {"requests": {"hashes": ["sha256:a", "sha256:b"], "version": "==2.32.3"}}, "develop": [], "_meta": {"requires": {"python_version": "3.12"}}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.PythonVersion()).To(Equal("3.12"))
+		})
+
+		it("stops reading after _meta", func() {
+			lock, err := project.ParsePipfileLock(strings.NewReader(`{"_meta": {"requires": {"python_version": "3.12"}}, "default": {`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.PythonVersion()).To(Equal("3.12"))
+		})
+
+		it("returns an empty string without _meta", func() {
+			lock, err := project.ParsePipfileLock(strings.NewReader(`{"default": {}}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lock.PythonVersion()).To(BeEmpty())
+		})
+
+		context("failure cases", func() {
+			it("fails on a truncated document", func() {
+				_, err := project.ParsePipfileLock(strings.NewReader("{"))
+				Expect(err).To(HaveOccurred())
+			})
+
+			it("fails when the document is not an object", func() {
+				_, err := project.ParsePipfileLock(strings.NewReader("[]"))
+				Expect(err).To(MatchError(ContainSubstring("expected")))
+			})
+		})
+	})
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitProject(t *testing.T) {
+	suite := spec.New("project", spec.Report(report.Terminal{}))
+	suite("Files", testFiles)
+	suite("Path", testPath)
+	suite("Project", testProject)
+	suite.Run(t)
+}
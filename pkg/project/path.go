@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// EnvProjectPath is the path of the python project relative to the
+	// application directory, e.g. services/api in a monorepo.
+	EnvProjectPath = "BP_PYTHON_PROJECT_PATH"
+
+	// EnvEffectiveProjectPath is the environment variable exported at build
+	// time with the absolute path of the python project.
+	EnvEffectiveProjectPath = "PYTHON_PROJECT_PATH"
+)
+
+// Path returns the directory containing the python project files. It is the
+// workingDir itself unless BP_PYTHON_PROJECT_PATH points to one of its
+// sub-directories.
+func Path(workingDir string) (string, error) {
+	value := strings.TrimSpace(os.Getenv(EnvProjectPath))
+	if value == "" {
+		return workingDir, nil
+	}
+
+	if filepath.IsAbs(value) {
+		return "", fmt.Errorf("%s must be relative to the application directory: %q", EnvProjectPath, value)
+	}
+
+	relative := filepath.Clean(value)
+	if relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s must not point outside of the application directory: %q", EnvProjectPath, value)
+	}
+
+	path := filepath.Join(workingDir, relative)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%s points to %q which does not exist", EnvProjectPath, value)
+		}
+		return "", err
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s points to %q which is not a directory", EnvProjectPath, value)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+
+	. "github.com/onsi/gomega"
+)
+
+func testPath(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		workingDir string
+	)
+
+	it.Before(func() {
+		workingDir = t.TempDir()
+		Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(workingDir, "README.md"), []byte{}, os.ModePerm)).To(Succeed())
+	})
+
+	it("returns the working directory by default", func() {
+		path, err := project.Path(workingDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(workingDir))
+	})
+
+	context("when BP_PYTHON_PROJECT_PATH is set", func() {
+		it.Before(func() {
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "./services/api/")
+		})
+
+		it("returns the project sub-directory", func() {
+			path, err := project.Path(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal(filepath.Join(workingDir, "services", "api")))
+		})
+	})
+
+	context("failure cases", func() {
+		context("when the path is absolute", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "/services/api")
+			})
+
+			it("returns an error", func() {
+				_, err := project.Path(workingDir)
+				Expect(err).To(MatchError(`BP_PYTHON_PROJECT_PATH must be relative to the application directory: "/services/api"`))
+			})
+		})
+
+		context("when the path points outside of the application", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/../../other")
+			})
+
+			it("returns an error", func() {
+				_, err := project.Path(workingDir)
+				Expect(err).To(MatchError(`BP_PYTHON_PROJECT_PATH must not point outside of the application directory: "services/../../other"`))
+			})
+		})
+
+		context("when the path does not exist", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+			})
+
+			it("returns an error", func() {
+				_, err := project.Path(workingDir)
+				Expect(err).To(MatchError(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`))
+			})
+		})
+
+		context("when the path is not a directory", func() {
+			it.Before(func() {
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "README.md")
+			})
+
+			it("returns an error", func() {
+				_, err := project.Path(workingDir)
+				Expect(err).To(MatchError(`BP_PYTHON_PROJECT_PATH points to "README.md" which is not a directory`))
+			})
+		})
+	})
+}
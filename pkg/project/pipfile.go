@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// pipfileRequires is the [requires] section of a Pipfile and the
+// _meta.requires object of a Pipfile.lock.
+type pipfileRequires struct {
+	PythonVersion     string `toml:"python_version" json:"python_version"`
+	PythonFullVersion string `toml:"python_full_version" json:"python_full_version"`
+}
+
+// version returns the most precise python version of the section.
+func (r pipfileRequires) version() string {
+	if r.PythonFullVersion != "" {
+		return strings.TrimSpace(r.PythonFullVersion)
+	}
+	return strings.TrimSpace(r.PythonVersion)
+}
+
+// Pipfile holds the parts of a Pipfile used during detection.
+type Pipfile struct {
+	requires pipfileRequires
+}
+
+// ParsePipfile decodes a Pipfile.
+func ParsePipfile(reader io.Reader) (*Pipfile, error) {
+	var content struct {
+		Requires pipfileRequires `toml:"requires"`
+	}
+
+	_, err := toml.NewDecoder(reader).Decode(&content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pipfile{requires: content.Requires}, nil
+}
+
+// PythonVersion returns the python version required by the Pipfile.
+// python_full_version takes precedence over python_version.
+func (p *Pipfile) PythonVersion() string {
+	if p == nil {
+		return ""
+	}
+	return p.requires.version()
+}
+
+// PipfileLock holds the _meta object of a Pipfile.lock.
+type PipfileLock struct {
+	requires pipfileRequires
+}
+
+// ParsePipfileLock decodes the _meta object of a Pipfile.lock. The hashes of
+// every locked package follow it, so the document is streamed and decoding
+// stops as soon as _meta has been read.
+func ParsePipfileLock(reader io.Reader) (*PipfileLock, error) {
+	decoder := json.NewDecoder(reader)
+
+	if err := expectDelim(decoder, '{'); err != nil {
+		return nil, err
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		if token != "_meta" {
+			if err := skipValue(decoder); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var meta struct {
+			Requires pipfileRequires `json:"requires"`
+		}
+		if err := decoder.Decode(&meta); err != nil {
+			return nil, err
+		}
+
+		return &PipfileLock{requires: meta.Requires}, nil
+	}
+
+	return &PipfileLock{}, nil
+}
+
+// PythonVersion returns the python version recorded in the Pipfile.lock.
+// python_full_version takes precedence over python_version.
+func (p *PipfileLock) PythonVersion() string {
+	if p == nil {
+		return ""
+	}
+	return p.requires.version()
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+
+	return nil
+}
+
+// skipValue consumes the next value of decoder without retaining it.
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
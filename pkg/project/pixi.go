@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PixiToml holds the parts of pixi.toml used during detection.
+type PixiToml struct {
+	python string
+}
+
+// ParsePixiToml decodes a pixi.toml.
+func ParsePixiToml(reader io.Reader) (*PixiToml, error) {
+	var content struct {
+		Dependencies map[string]toml.Primitive
+	}
+
+	metadata, err := toml.NewDecoder(reader).Decode(&content)
+	if err != nil {
+		return nil, err
+	}
+
+	pixiToml := &PixiToml{}
+
+	// The python dependency is either a version specification or a table
+	// with a version key.
+	if python, ok := content.Dependencies["python"]; ok {
+		if err := metadata.PrimitiveDecode(python, &pixiToml.python); err != nil {
+			var table struct {
+				Version string
+			}
+			if err := metadata.PrimitiveDecode(python, &table); err != nil {
+				return nil, err
+			}
+			pixiToml.python = table.Version
+		}
+	}
+
+	return pixiToml, nil
+}
+
+// PythonVersion returns the version specification of the python dependency.
+func (p *PixiToml) PythonVersion() string {
+	if p == nil {
+		return ""
+	}
+	return p.python
+}
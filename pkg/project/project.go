@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pythonversion"
+)
+
+// Names of the project files loaded by Load.
+const (
+	PyProjectFileName   = "pyproject.toml"
+	UvLockFileName      = "uv.lock"
+	PixiTomlFileName    = "pixi.toml"
+	PipfileName         = "Pipfile"
+	PipfileLockFileName = "Pipfile.lock"
+)
+
+// Project is the parsed content of the python project files of an
+// application. Files that are not present are nil. A file that cannot be
+// parsed only fails its accessor, so that the detectors which do not use it
+// are not affected.
+type Project struct {
+	// Path is the directory containing the project files, see Path.
+	Path string
+
+	pyProject   projectFile[PyProject]
+	uvLock      projectFile[UvLock]
+	pixiToml    projectFile[PixiToml]
+	pipfile     projectFile[Pipfile]
+	pipfileLock projectFile[PipfileLock]
+
+	// PythonVersionEntry is the first entry of .python-version, empty when
+	// the file does not exist.
+	PythonVersionEntry string
+}
+
+// projectFile is a parsed project file or the error of loading it.
+type projectFile[T any] struct {
+	content *T
+	err     error
+}
+
+// PyProject returns the parsed pyproject.toml.
+func (p Project) PyProject() (*PyProject, error) {
+	return p.pyProject.content, p.pyProject.err
+}
+
+// UvLock returns the parsed uv.lock.
+func (p Project) UvLock() (*UvLock, error) {
+	return p.uvLock.content, p.uvLock.err
+}
+
+// PixiToml returns the parsed pixi.toml.
+func (p Project) PixiToml() (*PixiToml, error) {
+	return p.pixiToml.content, p.pixiToml.err
+}
+
+// Pipfile returns the parsed Pipfile.
+func (p Project) Pipfile() (*Pipfile, error) {
+	return p.pipfile.content, p.pipfile.err
+}
+
+// PipfileLock returns the parsed Pipfile.lock.
+func (p Project) PipfileLock() (*PipfileLock, error) {
+	return p.pipfileLock.content, p.pipfileLock.err
+}
+
+// Loader loads the python project of an application directory.
+type Loader interface {
+	Load(workingDir string) (Project, error)
+}
+
+// FileLoader loads the project files from disk on every call.
+type FileLoader struct {
+}
+
+func NewLoader() FileLoader {
+	return FileLoader{}
+}
+
+// Load resolves the project directory of workingDir and parses the project
+// files it contains.
+func (l FileLoader) Load(workingDir string) (Project, error) {
+	path, err := Path(workingDir)
+	if err != nil {
+		return Project{}, err
+	}
+
+	project := Project{
+		Path:        path,
+		pyProject:   loadFile(filepath.Join(path, PyProjectFileName), ParsePyProject),
+		uvLock:      loadFile(filepath.Join(path, UvLockFileName), ParseUvLock),
+		pixiToml:    loadFile(filepath.Join(path, PixiTomlFileName), ParsePixiToml),
+		pipfile:     loadFile(filepath.Join(path, PipfileName), ParsePipfile),
+		pipfileLock: loadFile(filepath.Join(path, PipfileLockFileName), ParsePipfileLock),
+	}
+
+	project.PythonVersionEntry, err = pythonversion.Read(path)
+	if err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+// loadFile opens path and hands it to parse. The content is nil when the
+// file does not exist.
+func loadFile[T any](path string, parse func(io.Reader) (*T, error)) projectFile[T] {
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return projectFile[T]{}
+		}
+		return projectFile[T]{err: err}
+	}
+	defer file.Close()
+
+	content, err := parse(file)
+	if err != nil {
+		return projectFile[T]{err: fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)}
+	}
+
+	return projectFile[T]{content: content}
+}
+
+// CachedLoader loads the project of each working directory only once so
+// that the detectors of all the package managers can share it. Failed loads
+// are cached as well.
+type CachedLoader struct {
+	loader Loader
+
+	mutex    sync.Mutex
+	projects map[string]cachedProject
+}
+
+type cachedProject struct {
+	project Project
+	err     error
+}
+
+func NewCachedLoader(loader Loader) *CachedLoader {
+	return &CachedLoader{
+		loader:   loader,
+		projects: map[string]cachedProject{},
+	}
+}
+
+func (l *CachedLoader) Load(workingDir string) (Project, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	cached, ok := l.projects[workingDir]
+	if !ok {
+		cached.project, cached.err = l.loader.Load(workingDir)
+		l.projects[workingDir] = cached
+	}
+
+	return cached.project, cached.err
+}
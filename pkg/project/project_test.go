@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
+
+	. "github.com/onsi/gomega"
+)
+
+func testProject(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		workingDir string
+		loader     project.FileLoader
+	)
+
+	it.Before(func() {
+		workingDir = t.TempDir()
+		loader = project.NewLoader()
+	})
+
+	context("Load", func() {
+		it("returns an empty project without project files", func() {
+			proj, err := loader.Load(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proj).To(Equal(project.Project{Path: workingDir}))
+		})
+
+		context("with project files", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(workingDir, "pyproject.toml"), []byte(`[project]
+requires-python = ">=3.12"
+`), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, "uv.lock"), []byte(`version = 1
+requires-python = ">=3.12"
+`), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, "pixi.toml"), []byte(`[dependencies]
+python = "3.12.*"
+`), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte(`[requires]
+python_version = "3.12"
+`), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile.lock"), []byte(`{"_meta": {"requires": {"python_version": "3.12"}}}`), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("3.12.4\n"), 0644)).To(Succeed())
+			})
+
+			it("parses all of them", func() {
+				proj, err := loader.Load(workingDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proj.Path).To(Equal(workingDir))
+
+				pyProject, err := proj.PyProject()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pyProject.RequiresPython()).To(Equal(">=3.12"))
+
+				uvLock, err := proj.UvLock()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(uvLock.RequiresPython()).To(Equal(">=3.12"))
+
+				pixiToml, err := proj.PixiToml()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pixiToml.PythonVersion()).To(Equal("3.12.*"))
+
+				pipfile, err := proj.Pipfile()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipfile.PythonVersion()).To(Equal("3.12"))
+
+				pipfileLock, err := proj.PipfileLock()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pipfileLock.PythonVersion()).To(Equal("3.12"))
+
+				Expect(proj.PythonVersionEntry).To(Equal("3.12.4"))
+			})
+		})
+
+		context("when BP_PYTHON_PROJECT_PATH is set", func() {
+			it.Before(func() {
+				Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(workingDir, "services", "api", "pyproject.toml"), []byte("[tool.uv]\n"), 0644)).To(Succeed())
+				t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+			})
+
+			it("loads the project files of the sub-directory", func() {
+				proj, err := loader.Load(workingDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proj.Path).To(Equal(filepath.Join(workingDir, "services", "api")))
+
+				pyProject, err := proj.PyProject()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pyProject.HasTool("uv")).To(BeTrue())
+			})
+		})
+
+		context("failure cases", func() {
+			context("when BP_PYTHON_PROJECT_PATH is invalid", func() {
+				it.Before(func() {
+					t.Setenv("BP_PYTHON_PROJECT_PATH", "services/web")
+				})
+
+				it("returns an error", func() {
+					_, err := loader.Load(workingDir)
+					Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/web" which does not exist`)))
+				})
+			})
+
+			context("when a project file is malformed", func() {
+				it.Before(func() {
+					Expect(os.WriteFile(filepath.Join(workingDir, "pixi.toml"), []byte("%%%"), 0644)).To(Succeed())
+					Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte("[requires]\npython_version = \"3.12\"\n"), 0644)).To(Succeed())
+				})
+
+				it("returns an error naming the file from its accessor only", func() {
+					proj, err := loader.Load(workingDir)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = proj.PixiToml()
+					Expect(err).To(MatchError(ContainSubstring("failed to parse pixi.toml")))
+
+					pipfile, err := proj.Pipfile()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(pipfile.PythonVersion()).To(Equal("3.12"))
+				})
+			})
+
+			context("when a project file cannot be read", func() {
+				it.Before(func() {
+					Expect(os.Mkdir(filepath.Join(workingDir, "Pipfile"), os.ModePerm)).To(Succeed())
+				})
+
+				it("returns an error from its accessor", func() {
+					proj, err := loader.Load(workingDir)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = proj.Pipfile()
+					Expect(err).To(MatchError(ContainSubstring("failed to parse Pipfile")))
+				})
+			})
+		})
+	})
+
+	context("CachedLoader", func() {
+		it("loads each working directory only once", func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte("[requires]\npython_version = \"3.12\"\n"), 0644)).To(Succeed())
+
+			cached := project.NewCachedLoader(loader)
+
+			first, err := cached.Load(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.Remove(filepath.Join(workingDir, "Pipfile"))).To(Succeed())
+
+			second, err := cached.Load(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second).To(Equal(first))
+
+			pipfile, err := second.Pipfile()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pipfile.PythonVersion()).To(Equal("3.12"))
+		})
+
+		it("caches malformed project files", func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, "Pipfile"), []byte("%%%"), 0644)).To(Succeed())
+
+			cached := project.NewCachedLoader(loader)
+
+			first, err := cached.Load(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(os.Remove(filepath.Join(workingDir, "Pipfile"))).To(Succeed())
+
+			second, err := cached.Load(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = second.Pipfile()
+			Expect(err).To(MatchError(ContainSubstring("failed to parse Pipfile")))
+			Expect(second).To(Equal(first))
+		})
+
+		it("caches failed loads", func() {
+			t.Setenv("BP_PYTHON_PROJECT_PATH", "services/api")
+
+			cached := project.NewCachedLoader(loader)
+
+			_, err := cached.Load(workingDir)
+			Expect(err).To(HaveOccurred())
+
+			Expect(os.MkdirAll(filepath.Join(workingDir, "services", "api"), os.ModePerm)).To(Succeed())
+
+			_, err = cached.Load(workingDir)
+			Expect(err).To(MatchError(ContainSubstring(`BP_PYTHON_PROJECT_PATH points to "services/api" which does not exist`)))
+		})
+	})
+}
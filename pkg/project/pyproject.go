@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// PyProject holds the parts of pyproject.toml used during detection.
+type PyProject struct {
+	requiresPython string
+	poetryPython   string
+	buildBackend   string
+	hasBuildSystem bool
+	tools          map[string]bool
+}
+
+// ParsePyProject decodes a pyproject.toml.
+func ParsePyProject(reader io.Reader) (*PyProject, error) {
+	var content struct {
+		Project struct {
+			RequiresPython string `toml:"requires-python"`
+		}
+		Tool struct {
+			Poetry struct {
+				Dependencies struct {
+					Python string
+				}
+			}
+		}
+		BuildSystem struct {
+			BuildBackend string `toml:"build-backend"`
+		} `toml:"build-system"`
+	}
+
+	metadata, err := toml.NewDecoder(reader).Decode(&content)
+	if err != nil {
+		return nil, err
+	}
+
+	pyProject := &PyProject{
+		requiresPython: content.Project.RequiresPython,
+		poetryPython:   content.Tool.Poetry.Dependencies.Python,
+		buildBackend:   content.BuildSystem.BuildBackend,
+		hasBuildSystem: metadata.IsDefined("build-system"),
+		tools:          map[string]bool{},
+	}
+
+	for _, key := range metadata.Keys() {
+		if len(key) > 1 && key[0] == "tool" {
+			pyProject.tools[key[1]] = true
+		}
+	}
+
+	return pyProject, nil
+}
+
+// RequiresPython returns project.requires-python.
+func (p *PyProject) RequiresPython() string {
+	if p == nil {
+		return ""
+	}
+	return strings.Trim(p.requiresPython, "=")
+}
+
+// PoetryPython returns tool.poetry.dependencies.python.
+func (p *PyProject) PoetryPython() string {
+	if p == nil {
+		return ""
+	}
+	return strings.Trim(p.poetryPython, "=")
+}
+
+// BuildBackend returns build-system.build-backend.
+func (p *PyProject) BuildBackend() string {
+	if p == nil {
+		return ""
+	}
+	return p.buildBackend
+}
+
+// HasBuildSystem tells whether the [build-system] table is present.
+func (p *PyProject) HasBuildSystem() bool {
+	return p != nil && p.hasBuildSystem
+}
+
+// HasTool tells whether the [tool.<name>] table is present.
+func (p *PyProject) HasTool(name string) bool {
+	return p != nil && p.tools[name]
+}
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// UvLock holds the header of a uv.lock, that is the top level keys preceding
+// the first table.
+type UvLock struct {
+	version        int
+	requiresPython string
+}
+
+// ParseUvLock decodes the header of a uv.lock. Lock files list every package
+// of the resolution and easily weigh megabytes, so reading stops at the first
+// table.
+func ParseUvLock(reader io.Reader) (*UvLock, error) {
+	var header bytes.Buffer
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "[") {
+			break
+		}
+		header.WriteString(line)
+		header.WriteByte('\n')
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var content struct {
+		Version        int
+		RequiresPython string `toml:"requires-python"`
+	}
+
+	_, err := toml.NewDecoder(&header).Decode(&content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UvLock{
+		version:        content.Version,
+		requiresPython: content.RequiresPython,
+	}, nil
+}
+
+// Version returns the version of the lock file format.
+func (l *UvLock) Version() int {
+	if l == nil {
+		return 0
+	}
+	return l.version
+}
+
+// RequiresPython returns the requires-python the lock file was resolved for.
+func (l *UvLock) RequiresPython() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Trim(l.requiresPython, "=")
+}
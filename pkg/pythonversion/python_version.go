@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pythonversion
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+// FileName is the name of the file used by pyenv and uv to pin the python
+// interpreter of a project.
+const FileName = ".python-version"
+
+var versionPattern = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+// Requirement is a python version together with where it comes from.
+type Requirement struct {
+	Version string
+	Source  string
+}
+
+// Read returns the first entry of the .python-version file of workingDir. It
+// returns an empty string when the file does not exist.
+func Read(workingDir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(workingDir, FileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		return line, nil
+	}
+
+	return "", scanner.Err()
+}
+
+// Normalize converts an entry of .python-version to a cpython version. Only
+// plain X, X.Y and X.Y.Z versions, optionally prefixed by the "cpython@" or
+// "cpython-" implementation name used by uv, are supported.
+func Normalize(entry string) (string, bool) {
+	version := strings.TrimPrefix(entry, "cpython@")
+	if strings.HasPrefix(version, "cpython-") {
+		version, _, _ = strings.Cut(strings.TrimPrefix(version, "cpython-"), "-")
+	}
+
+	if !versionPattern.MatchString(version) {
+		return "", false
+	}
+
+	return version, true
+}
+
+// ResolveEntry applies the precedence rules between entry, as returned by
+// Read, and the version declared by the project.
+//
+// The pinned version is more precise than the ranges usually found in project
+// files so it is used whenever it satisfies the declared one. When both
+// conflict, the declared version wins and a warning is logged.
+func ResolveEntry(logger scribe.Emitter, entry string, declared Requirement) Requirement {
+	if entry == "" {
+		return declared
+	}
+
+	version, ok := Normalize(entry)
+	if !ok {
+		logger.Process("Warning: ignoring unsupported %s entry %q", FileName, entry)
+		logger.Break()
+		return declared
+	}
+
+	pinned := Requirement{Version: version, Source: FileName}
+
+	if declared.Version == "" || Satisfies(version, declared.Version) {
+		return pinned
+	}
+
+	logger.Process("Warning: %s pins python %s which does not satisfy %q from %s", FileName, version, declared.Version, declared.Source)
+	logger.Subprocess("Using %q from %s, update %s to remove this warning", declared.Version, declared.Source, FileName)
+	logger.Break()
+
+	return declared
+}
+
+// Satisfies returns whether version meets constraint. Constraints that cannot
+// be interpreted are considered satisfied as there is no way to tell they
+// conflict.
+func Satisfies(version, constraint string) bool {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return true
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return true
+	}
+
+	return c.Check(v)
+}
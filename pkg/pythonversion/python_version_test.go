@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pythonversion_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/pythonversion"
+
+	. "github.com/onsi/gomega"
+)
+
+func testPythonVersion(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		workingDir string
+		buffer     *bytes.Buffer
+		logger     scribe.Emitter
+	)
+
+	it.Before(func() {
+		workingDir = t.TempDir()
+		buffer = bytes.NewBuffer(nil)
+		logger = scribe.NewEmitter(buffer)
+	})
+
+	context("Read", func() {
+		it("returns an empty string without .python-version", func() {
+			entry, err := pythonversion.Read(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entry).To(BeEmpty())
+		})
+
+		it("returns the first entry skipping comments and blank lines", func() {
+			Expect(os.WriteFile(filepath.Join(workingDir, ".python-version"), []byte("# pinned by pyenv\n\n  3.12.1  \n3.11\n"), os.ModePerm)).To(Succeed())
+
+			entry, err := pythonversion.Read(workingDir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entry).To(Equal("3.12.1"))
+		})
+
+		context("failure cases", func() {
+			context("when .python-version cannot be read", func() {
+				it.Before(func() {
+					Expect(os.Mkdir(filepath.Join(workingDir, ".python-version"), os.ModePerm)).To(Succeed())
+				})
+
+				it("returns an error", func() {
+					_, err := pythonversion.Read(workingDir)
+					Expect(err).To(MatchError(ContainSubstring("is a directory")))
+				})
+			})
+		})
+	})
+
+	context("Normalize", func() {
+		it("accepts plain versions", func() {
+			for _, entry := range []string{"3", "3.12", "3.12.1"} {
+				version, ok := pythonversion.Normalize(entry)
+				Expect(ok).To(BeTrue())
+				Expect(version).To(Equal(entry))
+			}
+		})
+
+		it("strips the implementation names used by uv", func() {
+			version, ok := pythonversion.Normalize("cpython@3.12")
+			Expect(ok).To(BeTrue())
+			Expect(version).To(Equal("3.12"))
+
+			version, ok = pythonversion.Normalize("cpython-3.12.4-linux-x86_64-gnu")
+			Expect(ok).To(BeTrue())
+			Expect(version).To(Equal("3.12.4"))
+		})
+
+		it("rejects other interpreters and virtual environments", func() {
+			for _, entry := range []string{"system", "pypy3.10-7.3.12", "miniconda3-latest", "my-venv"} {
+				_, ok := pythonversion.Normalize(entry)
+				Expect(ok).To(BeFalse(), entry)
+			}
+		})
+	})
+
+	context("ResolveEntry", func() {
+		var declared pythonversion.Requirement
+
+		it.Before(func() {
+			declared = pythonversion.Requirement{Version: ">=3.10,<3.13", Source: "pyproject.toml"}
+		})
+
+		it("keeps the declared version without .python-version", func() {
+			Expect(pythonversion.ResolveEntry(logger, "", declared)).To(Equal(declared))
+		})
+
+		it("uses the pinned version when nothing is declared", func() {
+			Expect(pythonversion.ResolveEntry(logger, "3.12", pythonversion.Requirement{})).To(Equal(pythonversion.Requirement{Version: "3.12", Source: ".python-version"}))
+		})
+
+		it("uses the pinned version when it satisfies the declared one", func() {
+			Expect(pythonversion.ResolveEntry(logger, "3.12", declared)).To(Equal(pythonversion.Requirement{Version: "3.12", Source: ".python-version"}))
+			Expect(buffer.String()).To(BeEmpty())
+		})
+
+		context("when the pinned version conflicts with the declared one", func() {
+			it.Before(func() {
+				declared.Version = ">=3.13"
+			})
+
+			it("keeps the declared version and warns", func() {
+				Expect(pythonversion.ResolveEntry(logger, "3.12", declared)).To(Equal(declared))
+
+				Expect(buffer.String()).To(ContainSubstring(`Warning: .python-version pins python 3.12 which does not satisfy ">=3.13" from pyproject.toml`))
+				Expect(buffer.String()).To(ContainSubstring(`Using ">=3.13" from pyproject.toml, update .python-version to remove this warning`))
+			})
+		})
+
+		context("with an unsupported .python-version entry", func() {
+			it("keeps the declared version and warns", func() {
+				Expect(pythonversion.ResolveEntry(logger, "pypy3.10", declared)).To(Equal(declared))
+
+				Expect(buffer.String()).To(ContainSubstring(`Warning: ignoring unsupported .python-version entry "pypy3.10"`))
+			})
+		})
+	})
+
+	context("Satisfies", func() {
+		it("checks the version against the constraint", func() {
+			Expect(pythonversion.Satisfies("3.12.1", "~3.12")).To(BeTrue())
+			Expect(pythonversion.Satisfies("3.11", "^3.12")).To(BeFalse())
+		})
+
+		it("considers constraints it cannot interpret as satisfied", func() {
+			Expect(pythonversion.Satisfies("3.12", "not a constraint")).To(BeTrue())
+		})
+	})
+}
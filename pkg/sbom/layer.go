@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom
+
+import (
+	"time"
+
+	"github.com/paketo-buildpacks/packit/v2/chronos"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/sbom"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+)
+
+// GenerateForLayer generates the SBOM of the dependency installed in the
+// layer at path and returns it in the requested formats. The SBOM is built
+// from the dependency metadata only, which makes it cheap enough to be
+// regenerated when a cached layer is reused.
+func GenerateForLayer(
+	generator SBOMGenerator,
+	clock chronos.Clock,
+	logger scribe.Emitter,
+	dependency postal.Dependency,
+	path string,
+	formats []string,
+) (sbom.Formatter, error) {
+	logger.GeneratingSBOM(path)
+
+	var content sbom.SBOM
+	duration, err := clock.Measure(func() error {
+		var err error
+		content, err = generator.GenerateFromDependency(dependency, path)
+		return err
+	})
+	if err != nil {
+		return sbom.Formatter{}, err
+	}
+
+	logger.Action("Completed in %s", duration.Round(time.Millisecond))
+	logger.Break()
+
+	logger.FormattingSBOM(formats...)
+	return content.InFormats(formats...)
+}
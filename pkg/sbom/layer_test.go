@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sbom_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paketo-buildpacks/packit/v2/chronos"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/sbom"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	pythonsbom "github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/fakes"
+
+	. "github.com/onsi/gomega"
+)
+
+func testGenerateForLayer(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		generator  *fakes.SBOMGenerator
+		clock      chronos.Clock
+		buffer     *bytes.Buffer
+		dependency postal.Dependency
+	)
+
+	it.Before(func() {
+		generator = &fakes.SBOMGenerator{}
+		generator.GenerateFromDependencyCall.Returns.SBOM = sbom.SBOM{}
+
+		clock = chronos.NewClock(func() time.Time {
+			return time.Unix(0, 0)
+		})
+
+		buffer = bytes.NewBuffer(nil)
+
+		dependency = postal.Dependency{
+			ID:      "some-dependency",
+			Version: "1.2.3",
+		}
+	})
+
+	it("generates the SBOM of the layer in the requested formats", func() {
+		formatter, err := pythonsbom.GenerateForLayer(
+			generator,
+			clock,
+			scribe.NewEmitter(buffer),
+			dependency,
+			"/some/layer",
+			[]string{sbom.CycloneDXFormat, sbom.SPDXFormat},
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(generator.GenerateFromDependencyCall.Receives.Dependency).To(Equal(dependency))
+		Expect(generator.GenerateFromDependencyCall.Receives.Dir).To(Equal("/some/layer"))
+
+		var extensions []string
+		for _, format := range formatter.Formats() {
+			extensions = append(extensions, format.Extension)
+		}
+		Expect(extensions).To(ConsistOf("cdx.json", "spdx.json"))
+
+		Expect(buffer.String()).To(ContainSubstring("Generating SBOM for /some/layer"))
+	})
+
+	context("failure cases", func() {
+		context("when the SBOM cannot be generated", func() {
+			it.Before(func() {
+				generator.GenerateFromDependencyCall.Returns.Error = errors.New("failed to generate SBOM")
+			})
+
+			it("returns an error", func() {
+				_, err := pythonsbom.GenerateForLayer(generator, clock, scribe.NewEmitter(buffer), dependency, "/some/layer", nil)
+				Expect(err).To(MatchError("failed to generate SBOM"))
+			})
+		})
+
+		context("when a format is not supported", func() {
+			it("returns an error", func() {
+				_, err := pythonsbom.GenerateForLayer(generator, clock, scribe.NewEmitter(buffer), dependency, "/some/layer", []string{"random-format"})
+				Expect(err).To(MatchError("unsupported SBOM format: 'random-format'"))
+			})
+		})
+	})
+}
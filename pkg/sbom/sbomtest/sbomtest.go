@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sbomtest holds the assertions shared by the tests of the installers
+// about the SBOM of their layers.
+package sbomtest
+
+import (
+	"testing"
+
+	"github.com/paketo-buildpacks/packit/v2"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom/fakes"
+
+	. "github.com/onsi/gomega"
+)
+
+// ExpectLayerSBOM checks that layer holds the CycloneDX and SPDX SBOM of the
+// dependency generator was asked to describe in path.
+func ExpectLayerSBOM(t *testing.T, layer packit.Layer, generator *fakes.SBOMGenerator, path string) {
+	t.Helper()
+
+	Expect := NewWithT(t).Expect
+
+	Expect(layer.SBOM.Formats()).To(HaveLen(2))
+	var actualExtensions []string
+	for _, format := range layer.SBOM.Formats() {
+		actualExtensions = append(actualExtensions, format.Extension)
+	}
+	Expect(actualExtensions).To(ConsistOf("cdx.json", "spdx.json"))
+	Expect(generator.GenerateFromDependencyCall.Receives.Dir).To(Equal(path))
+}
@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualenv_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitVirtualenv(t *testing.T) {
+	suite := spec.New("virtualenv", spec.Report(report.Terminal{}))
+	suite("InstallMode", testInstallMode)
+	suite("InstallProcess", testInstallProcess)
+	suite.Run(t)
+}
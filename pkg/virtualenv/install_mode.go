@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualenv
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	// ModeVirtualenv denotes a package manager installed in its own virtual
+	// environment with only its entrypoints exposed.
+	ModeVirtualenv = "virtualenv"
+
+	// ModeUser denotes a package manager installed with `pip install --user`
+	// and made available through PYTHONPATH.
+	ModeUser = "user"
+)
+
+// GetInstallMode returns the install mode to use for a Python based package
+// manager. The legacy user install mode is selected when the given
+// environment variable is set to true.
+func GetInstallMode(userInstallEnv string) (string, error) {
+	value, ok := os.LookupEnv(userInstallEnv)
+	if !ok || value == "" {
+		return ModeVirtualenv, nil
+	}
+
+	userInstall, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid value for %s: %w", userInstallEnv, err)
+	}
+
+	if userInstall {
+		return ModeUser, nil
+	}
+	return ModeVirtualenv, nil
+}
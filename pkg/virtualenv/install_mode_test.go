@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualenv_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/virtualenv"
+
+	. "github.com/onsi/gomega"
+)
+
+func testInstallMode(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	const envName = "BP_SOME_TOOL_USER_INSTALL"
+
+	context("when the environment variable is not set", func() {
+		it("selects the virtualenv mode", func() {
+			mode, err := virtualenv.GetInstallMode(envName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mode).To(Equal(virtualenv.ModeVirtualenv))
+		})
+	})
+
+	context("when the environment variable is true", func() {
+		it.Before(func() {
+			t.Setenv(envName, "true")
+		})
+
+		it("selects the user mode", func() {
+			mode, err := virtualenv.GetInstallMode(envName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mode).To(Equal(virtualenv.ModeUser))
+		})
+	})
+
+	context("when the environment variable is false", func() {
+		it.Before(func() {
+			t.Setenv(envName, "false")
+		})
+
+		it("selects the virtualenv mode", func() {
+			mode, err := virtualenv.GetInstallMode(envName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mode).To(Equal(virtualenv.ModeVirtualenv))
+		})
+	})
+
+	context("when the environment variable is not a boolean", func() {
+		it.Before(func() {
+			t.Setenv(envName, "sometimes")
+		})
+
+		it("returns an error", func() {
+			_, err := virtualenv.GetInstallMode(envName)
+			Expect(err).To(MatchError(ContainSubstring("invalid value for BP_SOME_TOOL_USER_INSTALL")))
+		})
+	})
+}
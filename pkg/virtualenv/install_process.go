@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualenv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/paketo-buildpacks/packit/v2/fs"
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable"
+)
+
+// VenvDirectory is the name of the directory, within a layer, containing
+// the virtual environment.
+const VenvDirectory = "venv"
+
+// InstallProcess installs a Python package in its own virtual environment
+// within a layer and only exposes its entrypoints in the bin directory of
+// that layer. This keeps the package dependencies out of the application
+// import path.
+type InstallProcess struct {
+	executable executable.Executable
+	logger     scribe.Emitter
+}
+
+// NewInstallProcess creates an instance of the InstallProcess given an
+// Executable that runs `python`.
+func NewInstallProcess(executable executable.Executable, logger scribe.Emitter) InstallProcess {
+	return InstallProcess{
+		executable: executable,
+		logger:     logger,
+	}
+}
+
+// Execute creates a virtual environment in the targetLayerPath, installs the
+// requirements in it using the pip found in pipLayerPath and links the given
+// entrypoints in the bin directory of the layer. The requirements are passed
+// as is to pip install and may hold options such as --find-links.
+func (p InstallProcess) Execute(targetLayerPath, pipLayerPath string, requirements []string, entrypoints ...string) error {
+	venvPath := filepath.Join(targetLayerPath, VenvDirectory)
+
+	output, buffer := executable.NewOutputWriter(p.logger)
+	err := p.executable.Execute(pexec.Execution{
+		// The virtual environment is created without pip as the one from the
+		// pip layer is used to populate it.
+		Args:   []string{"-m", "venv", "--without-pip", venvPath},
+		Stdout: output,
+		Stderr: output,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create virtual environment:\n%s\nerror: %w", buffer.String(), err)
+	}
+
+	output, buffer = executable.NewOutputWriter(p.logger)
+	err = p.executable.Execute(pexec.Execution{
+		Args: append([]string{"-m", "pip", "--python", filepath.Join(venvPath, "bin", "python"), "install"}, requirements...),
+		// Set the PYTHONUSERBASE to ensure that the pip installed in the pip layer is used.
+		Env:    append(os.Environ(), fmt.Sprintf("PYTHONUSERBASE=%s", pipLayerPath)),
+		Stdout: output,
+		Stderr: output,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to install %s in virtual environment:\n%s\nerror: %w", strings.Join(requirements, " "), buffer.String(), err)
+	}
+
+	binPath := filepath.Join(targetLayerPath, "bin")
+	err = os.MkdirAll(binPath, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	for _, entrypoint := range entrypoints {
+		exists, err := fs.Exists(filepath.Join(venvPath, "bin", entrypoint))
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("failed to find entrypoint %s in virtual environment", entrypoint)
+		}
+
+		err = os.Symlink(filepath.Join("..", VenvDirectory, "bin", entrypoint), filepath.Join(binPath, entrypoint))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
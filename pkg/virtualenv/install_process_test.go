@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualenv_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/packit/v2/pexec"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/executable/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/virtualenv"
+
+	. "github.com/onsi/gomega"
+)
+
+func testInstallProcess(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		targetLayerPath string
+		pipLayerPath    string
+		executable      *fakes.Executable
+		executions      []pexec.Execution
+		buffer          *bytes.Buffer
+
+		installProcess virtualenv.InstallProcess
+	)
+
+	it.Before(func() {
+		targetLayerPath = t.TempDir()
+		pipLayerPath = t.TempDir()
+
+		executions = nil
+		executable = &fakes.Executable{}
+		executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+			executions = append(executions, execution)
+
+			// Simulate the entrypoint installed by pip
+			if execution.Args[1] == "pip" {
+				binPath := filepath.Join(targetLayerPath, "venv", "bin")
+				Expect(os.MkdirAll(binPath, os.ModePerm)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(binPath, "some-tool"), nil, 0755)).To(Succeed())
+			}
+			return nil
+		}
+
+		buffer = bytes.NewBuffer(nil)
+
+		installProcess = virtualenv.NewInstallProcess(executable, scribe.NewEmitter(buffer))
+	})
+
+	context("Execute", func() {
+		it("installs the requirement in a virtual environment and links its entrypoints", func() {
+			err := installProcess.Execute(targetLayerPath, pipLayerPath, []string{"some-tool==1.2.3"}, "some-tool")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(executions).To(HaveLen(2))
+			Expect(executions[0].Args).To(Equal([]string{"-m", "venv", "--without-pip", filepath.Join(targetLayerPath, "venv")}))
+			Expect(executions[1].Args).To(Equal([]string{
+				"-m", "pip",
+				"--python", filepath.Join(targetLayerPath, "venv", "bin", "python"),
+				"install", "some-tool==1.2.3",
+			}))
+			Expect(executions[1].Env).To(Equal(append(os.Environ(), fmt.Sprintf("PYTHONUSERBASE=%s", pipLayerPath))))
+
+			link, err := os.Readlink(filepath.Join(targetLayerPath, "bin", "some-tool"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(link).To(Equal(filepath.Join("..", "venv", "bin", "some-tool")))
+		})
+
+		context("when the requirements hold pip options", func() {
+			it("passes them as is to pip install", func() {
+				err := installProcess.Execute(targetLayerPath, pipLayerPath, []string{"--no-index", "--find-links", "some-dir", "--requirement", "some-dir/requirements.txt"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(executions[1].Args).To(Equal([]string{
+					"-m", "pip",
+					"--python", filepath.Join(targetLayerPath, "venv", "bin", "python"),
+					"install", "--no-index", "--find-links", "some-dir", "--requirement", "some-dir/requirements.txt",
+				}))
+			})
+		})
+
+		context("when the log level is debug", func() {
+			it.Before(func() {
+				installProcess = virtualenv.NewInstallProcess(executable, scribe.NewEmitter(buffer).WithLevel("DEBUG"))
+				executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+					_, err := fmt.Fprintln(execution.Stdout, "stdout output")
+					Expect(err).NotTo(HaveOccurred())
+					return nil
+				}
+			})
+
+			it("streams the process output", func() {
+				err := installProcess.Execute(targetLayerPath, pipLayerPath, []string{"some-tool==1.2.3"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(buffer.String()).To(ContainSubstring("      stdout output\n"))
+			})
+		})
+
+		context("failure cases", func() {
+			context("when the virtual environment cannot be created", func() {
+				it.Before(func() {
+					executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+						_, err := fmt.Fprintln(execution.Stderr, "stderr output")
+						Expect(err).NotTo(HaveOccurred())
+						return errors.New("venv failed")
+					}
+				})
+
+				it("returns an error", func() {
+					err := installProcess.Execute(targetLayerPath, pipLayerPath, []string{"some-tool==1.2.3"}, "some-tool")
+					Expect(err).To(MatchError(ContainSubstring("failed to create virtual environment")))
+					Expect(err).To(MatchError(ContainSubstring("stderr output")))
+					Expect(err).To(MatchError(ContainSubstring("venv failed")))
+				})
+			})
+
+			context("when the requirement cannot be installed", func() {
+				it.Before(func() {
+					executable.ExecuteCall.Stub = func(execution pexec.Execution) error {
+						if execution.Args[1] == "pip" {
+							_, err := fmt.Fprintln(execution.Stdout, "stdout output")
+							Expect(err).NotTo(HaveOccurred())
+							return errors.New("pip failed")
+						}
+						return nil
+					}
+				})
+
+				it("returns an error", func() {
+					err := installProcess.Execute(targetLayerPath, pipLayerPath, []string{"some-tool==1.2.3"}, "some-tool")
+					Expect(err).To(MatchError(ContainSubstring("failed to install some-tool==1.2.3 in virtual environment")))
+					Expect(err).To(MatchError(ContainSubstring("stdout output")))
+					Expect(err).To(MatchError(ContainSubstring("pip failed")))
+				})
+			})
+
+			context("when an entrypoint is missing", func() {
+				it("returns an error", func() {
+					err := installProcess.Execute(targetLayerPath, pipLayerPath, []string{"some-tool==1.2.3"}, "other-tool")
+					Expect(err).To(MatchError("failed to find entrypoint other-tool in virtual environment"))
+				})
+			})
+		})
+	})
+}
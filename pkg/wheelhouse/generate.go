@@ -0,0 +1,10 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wheelhouse
+
+// The retrieval of the dependencies, a module of its own, uses a copy of
+// id.go rather than depending on the buildpack.
+//go:generate sh -c "mkdir -p ../../dependency/retrieval/wheelhouse && { echo '// Code generated from pkg/wheelhouse/id.go by go generate. DO NOT EDIT.'; echo; cat id.go; } > ../../dependency/retrieval/wheelhouse/id.go"
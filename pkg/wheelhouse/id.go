@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wheelhouse
+
+import "regexp"
+
+// idPattern matches the id of a wheelhouse: the id of its tool followed by
+// the python ABI, free-threaded or not.
+var idPattern = regexp.MustCompile(`^(.+)-(cp\d+t?)$`)
+
+// ID returns the id of the wheelhouses of tool for the python ABI, e.g.
+// poetry-cp312. Each ABI has an id of its own so that postal resolves the
+// wheelhouse of the interpreter of the build, rather than failing on several
+// dependencies supporting the wildcard stack for the same version.
+func ID(tool, abi string) string {
+	return tool + "-" + abi
+}
+
+// Parse returns the tool and the python ABI of the wheelhouse id, ok is
+// false when id is not the one of a wheelhouse.
+func Parse(id string) (tool, abi string, ok bool) {
+	matches := idPattern.FindStringSubmatch(id)
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], matches[2], true
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wheelhouse_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
+
+	. "github.com/onsi/gomega"
+)
+
+func testID(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("ID", func() {
+		it("appends the python ABI to the id of the tool", func() {
+			Expect(wheelhouse.ID("poetry", "cp312")).To(Equal("poetry-cp312"))
+		})
+	})
+
+	context("Parse", func() {
+		it("returns the tool and the python ABI of a wheelhouse", func() {
+			tool, abi, ok := wheelhouse.Parse("poetry-cp312")
+			Expect(ok).To(BeTrue())
+			Expect(tool).To(Equal("poetry"))
+			Expect(abi).To(Equal("cp312"))
+
+			tool, abi, ok = wheelhouse.Parse("some-tool-cp313t")
+			Expect(ok).To(BeTrue())
+			Expect(tool).To(Equal("some-tool"))
+			Expect(abi).To(Equal("cp313t"))
+		})
+
+		it("rejects the other ids", func() {
+			for _, id := range []string{"poetry", "miniconda3", "cp312", "poetry-cp"} {
+				_, _, ok := wheelhouse.Parse(id)
+				Expect(ok).To(BeFalse(), id)
+			}
+		})
+	})
+
+	context("the copy of the retrieval", func() {
+		it("is up to date, see go generate", func() {
+			source, err := os.ReadFile("id.go")
+			Expect(err).NotTo(HaveOccurred())
+
+			generated, err := os.ReadFile("../../dependency/retrieval/wheelhouse/id.go")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(generated)).To(Equal("// Code generated from pkg/wheelhouse/id.go by go generate. DO NOT EDIT.\n\n" + string(source)))
+		})
+	})
+}
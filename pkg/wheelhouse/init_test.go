@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wheelhouse_test
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+)
+
+func TestUnitWheelhouse(t *testing.T) {
+	suite := spec.New("wheelhouse", spec.Report(report.Terminal{}))
+	suite("ID", testID)
+	suite("Wheelhouse", testWheelhouse)
+	suite.Run(t)
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wheelhouse installs the python tools from their wheelhouses: the
+// wheels of a tool and of its dependencies for a python ABI, along with a
+// requirements.txt pinning each of them by its SHA-256, as compiled by
+// dependency/actions/compile.
+package wheelhouse
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency"
+)
+
+// RequirementsFile is the file of a wheelhouse pinning each of its wheels.
+const RequirementsFile = "requirements.txt"
+
+// InstallArgs returns the arguments of pip install installing the wheels of
+// the wheelhouse unpacked in dir, without network access.
+func InstallArgs(dir string) []string {
+	return []string{
+		"--no-index",
+		"--find-links", dir,
+		"--require-hashes",
+		"--requirement", filepath.Join(dir, RequirementsFile),
+	}
+}
+
+// Resolve returns the wheelhouse of version of tool for the python ABI,
+// found is false when buildpack.toml has none.
+func Resolve(manager dependency.DependencyManager, cnbPath, tool, version, abi, stack string) (wheelhouse postal.Dependency, found bool, err error) {
+	if abi == "" {
+		return postal.Dependency{}, false, nil
+	}
+
+	wheelhouse, err = manager.Resolve(filepath.Join(cnbPath, "buildpack.toml"), ID(tool, abi), version, stack)
+	var noDeps *postal.ErrNoDeps
+	if errors.As(err, &noDeps) {
+		return postal.Dependency{}, false, nil
+	}
+	if err != nil {
+		return postal.Dependency{}, false, err
+	}
+
+	return wheelhouse, true, nil
+}
+
+// Requirements returns the arguments of pip install installing version of
+// tool for the python ABI: its wheelhouse, delivered into a temporary layer,
+// or requirement from PyPI when buildpack.toml has no wheelhouse for them.
+func Requirements(context packit.BuildContext, manager dependency.DependencyManager, logger scribe.Emitter, tool, version, abi, requirement string) ([]string, error) {
+	wheelhouse, found, err := Resolve(manager, context.CNBPath, tool, version, abi, context.Stack)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		logger.Subprocess("No wheelhouse of %s %s for the python interpreter, installing from PyPI", tool, version)
+		return []string{requirement}, nil
+	}
+
+	// The layer has no type set, the lifecycle removes it once the build
+	// is over
+	layer, err := context.Layers.Get(tool + "-wheelhouse")
+	if err != nil {
+		return nil, err
+	}
+
+	layer, err = layer.Reset()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Subprocess("Installing from the wheelhouse %s", wheelhouse.ID)
+	err = manager.Deliver(wheelhouse, context.CNBPath, layer.Path, context.Platform.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return InstallArgs(layer.Path), nil
+}
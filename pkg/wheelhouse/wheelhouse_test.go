@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: © 2026 Idiap Research Institute <contact@idiap.ch>
+// SPDX-FileContributor: Samuel Gaist <samuel.gaist@idiap.ch>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package wheelhouse_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paketo-buildpacks/packit/v2"
+	"github.com/paketo-buildpacks/packit/v2/postal"
+	"github.com/paketo-buildpacks/packit/v2/scribe"
+	"github.com/sclevine/spec"
+
+	dependencyfakes "github.com/paketo-buildpacks/python-package-managers-install/pkg/dependency/fakes"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/wheelhouse"
+
+	. "github.com/onsi/gomega"
+)
+
+func testWheelhouse(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		layersDir string
+
+		dependencyManager *dependencyfakes.DependencyManager
+		buildContext      packit.BuildContext
+
+		buffer *bytes.Buffer
+		logger scribe.Emitter
+	)
+
+	it.Before(func() {
+		var err error
+		layersDir, err = os.MkdirTemp("", "layers")
+		Expect(err).NotTo(HaveOccurred())
+
+		dependencyManager = &dependencyfakes.DependencyManager{}
+		dependencyManager.ResolveCall.Returns.Dependency = postal.Dependency{
+			ID:      "poetry-cp312",
+			Version: "2.3.2",
+		}
+
+		buildContext = packit.BuildContext{
+			CNBPath:  "cnb",
+			Platform: packit.Platform{Path: "platform"},
+			Layers:   packit.Layers{Path: layersDir},
+			Stack:    "some-stack",
+		}
+
+		buffer = bytes.NewBuffer(nil)
+		logger = scribe.NewEmitter(buffer)
+	})
+
+	it.After(func() {
+		Expect(os.RemoveAll(layersDir)).To(Succeed())
+	})
+
+	context("InstallArgs", func() {
+		it("installs the wheels of the wheelhouse by hash without network access", func() {
+			Expect(wheelhouse.InstallArgs("some-dir")).To(Equal([]string{
+				"--no-index",
+				"--find-links", "some-dir",
+				"--require-hashes",
+				"--requirement", filepath.Join("some-dir", "requirements.txt"),
+			}))
+		})
+	})
+
+	context("Resolve", func() {
+		it("resolves the wheelhouse of the python ABI", func() {
+			dependency, found, err := wheelhouse.Resolve(dependencyManager, "cnb", "poetry", "2.3.2", "cp312", "some-stack")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(dependency.ID).To(Equal("poetry-cp312"))
+
+			Expect(dependencyManager.ResolveCall.Receives.Path).To(Equal(filepath.Join("cnb", "buildpack.toml")))
+			Expect(dependencyManager.ResolveCall.Receives.Id).To(Equal("poetry-cp312"))
+			Expect(dependencyManager.ResolveCall.Receives.Version).To(Equal("2.3.2"))
+			Expect(dependencyManager.ResolveCall.Receives.Stack).To(Equal("some-stack"))
+		})
+
+		context("when buildpack.toml has no wheelhouse for the python ABI", func() {
+			it.Before(func() {
+				dependencyManager.ResolveCall.Returns.Error = &postal.ErrNoDeps{}
+			})
+
+			it("is not found", func() {
+				_, found, err := wheelhouse.Resolve(dependencyManager, "cnb", "poetry", "2.3.2", "cp312", "some-stack")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		context("when the python ABI is not known", func() {
+			it("is not found", func() {
+				_, found, err := wheelhouse.Resolve(dependencyManager, "cnb", "poetry", "2.3.2", "", "some-stack")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+				Expect(dependencyManager.ResolveCall.CallCount).To(Equal(0))
+			})
+		})
+
+		context("when resolving fails", func() {
+			it.Before(func() {
+				dependencyManager.ResolveCall.Returns.Error = errors.New("failed to resolve")
+			})
+
+			it("returns the error", func() {
+				_, _, err := wheelhouse.Resolve(dependencyManager, "cnb", "poetry", "2.3.2", "cp312", "some-stack")
+				Expect(err).To(MatchError("failed to resolve"))
+			})
+		})
+	})
+
+	context("Requirements", func() {
+		it("delivers the wheelhouse into a temporary layer and installs from it", func() {
+			requirements, err := wheelhouse.Requirements(buildContext, dependencyManager, logger, "poetry", "2.3.2", "cp312", "poetry==2.3.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requirements).To(Equal(wheelhouse.InstallArgs(filepath.Join(layersDir, "poetry-wheelhouse"))))
+
+			Expect(dependencyManager.DeliverCall.Receives.Dependency.ID).To(Equal("poetry-cp312"))
+			Expect(dependencyManager.DeliverCall.Receives.CnbPath).To(Equal("cnb"))
+			Expect(dependencyManager.DeliverCall.Receives.DestinationPath).To(Equal(filepath.Join(layersDir, "poetry-wheelhouse")))
+			Expect(dependencyManager.DeliverCall.Receives.PlatformPath).To(Equal("platform"))
+
+			Expect(buffer.String()).To(ContainSubstring("Installing from the wheelhouse poetry-cp312"))
+		})
+
+		context("when buildpack.toml has no wheelhouse for the python ABI", func() {
+			it.Before(func() {
+				dependencyManager.ResolveCall.Returns.Error = &postal.ErrNoDeps{}
+			})
+
+			it("installs the requirement from PyPI", func() {
+				requirements, err := wheelhouse.Requirements(buildContext, dependencyManager, logger, "poetry", "2.3.2", "cp312", "poetry==2.3.2")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(requirements).To(Equal([]string{"poetry==2.3.2"}))
+
+				Expect(dependencyManager.DeliverCall.CallCount).To(Equal(0))
+				Expect(buffer.String()).To(ContainSubstring("No wheelhouse of poetry 2.3.2 for the python interpreter, installing from PyPI"))
+			})
+		})
+
+		context("failure cases", func() {
+			context("when resolving fails", func() {
+				it.Before(func() {
+					dependencyManager.ResolveCall.Returns.Error = errors.New("failed to resolve")
+				})
+
+				it("returns the error", func() {
+					_, err := wheelhouse.Requirements(buildContext, dependencyManager, logger, "poetry", "2.3.2", "cp312", "poetry==2.3.2")
+					Expect(err).To(MatchError("failed to resolve"))
+				})
+			})
+
+			context("when the delivery fails", func() {
+				it.Before(func() {
+					dependencyManager.DeliverCall.Returns.Error = errors.New("failed to deliver")
+				})
+
+				it("returns the error", func() {
+					_, err := wheelhouse.Requirements(buildContext, dependencyManager, logger, "poetry", "2.3.2", "cp312", "poetry==2.3.2")
+					Expect(err).To(MatchError("failed to deliver"))
+				})
+			})
+		})
+	})
+}
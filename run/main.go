@@ -23,7 +23,10 @@ import (
 	pixi "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/pixi"
 	poetry "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/poetry"
 	uv "github.com/paketo-buildpacks/python-package-managers-install/pkg/installers/uv"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/interpreter"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/project"
 	"github.com/paketo-buildpacks/python-package-managers-install/pkg/sbom"
+	"github.com/paketo-buildpacks/python-package-managers-install/pkg/virtualenv"
 )
 
 func main() {
@@ -38,22 +41,27 @@ func main() {
 	packagerParameters := map[string]pythoninstallers.PackagerParameters{
 		miniconda.Conda: miniconda.CondaBuildParameters{
 			DependencyManager: postal.NewService(cargo.NewTransport()),
-			Runner:            miniconda.NewScriptRunner(pexec.NewExecutable("bash")),
+			Runner:            miniconda.NewScriptRunner(pexec.NewExecutable("bash"), logger),
 		},
 		pip.Pip: pip.PipBuildParameters{
 			DependencyManager:  postal.NewService(cargo.NewTransport()),
-			InstallProcess:     pip.NewPipInstallProcess(pexec.NewExecutable("python")),
-			SitePackageProcess: pip.NewSiteProcess(pexec.NewExecutable("python")),
+			InstallProcess:     pip.NewPipInstallProcess(pexec.NewExecutable("python"), logger),
+			SitePackageProcess: pip.NewSiteProcess(pexec.NewExecutable("python"), logger),
+			InterpreterProcess: interpreter.NewProcess(pexec.NewExecutable("python"), logger),
 		},
 		pipenv.Pipenv: pipenv.PipEnvBuildParameters{
-			DependencyManager:  postal.NewService(cargo.NewTransport()),
-			InstallProcess:     pipenv.NewPipenvInstallProcess(pexec.NewExecutable("pip")),
-			SitePackageProcess: pipenv.NewSiteProcess(pexec.NewExecutable("python")),
+			DependencyManager:        postal.NewService(cargo.NewTransport()),
+			InstallProcess:           pipenv.NewPipenvInstallProcess(pexec.NewExecutable("pip"), logger),
+			SitePackageProcess:       pipenv.NewSiteProcess(pexec.NewExecutable("python"), logger),
+			VirtualenvInstallProcess: virtualenv.NewInstallProcess(pexec.NewExecutable("python"), logger),
+			InterpreterProcess:       interpreter.NewProcess(pexec.NewExecutable("python"), logger),
 		},
 		poetry.PoetryDependency: poetry.PoetryBuildParameters{
-			DependencyManager:  postal.NewService(cargo.NewTransport()),
-			InstallProcess:     poetry.NewPoetryInstallProcess(pexec.NewExecutable("python")),
-			SitePackageProcess: poetry.NewSiteProcess(pexec.NewExecutable("python")),
+			DependencyManager:        postal.NewService(cargo.NewTransport()),
+			InstallProcess:           poetry.NewPoetryInstallProcess(pexec.NewExecutable("python"), logger),
+			SitePackageProcess:       poetry.NewSiteProcess(pexec.NewExecutable("python"), logger),
+			VirtualenvInstallProcess: virtualenv.NewInstallProcess(pexec.NewExecutable("python"), logger),
+			InterpreterProcess:       interpreter.NewProcess(pexec.NewExecutable("python"), logger),
 		},
 		uv.Uv: uv.UvBuildParameters{
 			DependencyManager: postal.NewService(cargo.NewTransport()),
@@ -66,7 +74,7 @@ func main() {
 	}
 
 	packit.Run(
-		pythoninstallers.Detect(logger, poetry.NewPyProjectParser()),
+		pythoninstallers.Detect(logger, project.NewLoader()),
 		pythoninstallers.Build(logger, buildParameters, packagerParameters),
 	)
 }